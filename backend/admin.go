@@ -0,0 +1,355 @@
+package backend
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	adminKickCloseReason = "kicked by admin"
+	adminRoomCloseReason = "closed_by_admin"
+)
+
+// 관리자 API 클라이언트 정보
+type AdminClientInfo struct {
+	ID         string `json:"id"`
+	Nickname   string `json:"nickname"`
+	Color      string `json:"color"`
+	Character  string `json:"character"`
+	RoomID     string `json:"room_id,omitempty"`
+	RemoteAddr string `json:"remote_addr"`
+	Replay     bool   `json:"watching_replay"`
+}
+
+// 관리자 API 방 정보
+type AdminRoomInfo struct {
+	ID         string            `json:"id"`
+	OwnerID    string            `json:"owner_id"`
+	State      RoomState         `json:"state"`
+	MaxPlayers int               `json:"max_players"`
+	Settings   RoomSettings      `json:"settings"`
+	Players    []AdminPlayerInfo `json:"players"`
+	Game       *AdminGameInfo    `json:"game,omitempty"`
+}
+
+// 관리자 API 방 플레이어 정보
+// 게임 중이면 실시간 점수, 체력 포함
+type AdminPlayerInfo struct {
+	PlayerInfo
+	Score       *int `json:"score,omitempty"`
+	Health      *int `json:"health,omitempty"`
+	IsAlive     bool `json:"is_alive,omitempty"`
+	IsConnected bool `json:"is_connected,omitempty"`
+
+	// 입력 검증 결과 (시간이 지나면 점수 감소)
+	Suspicion        *float64       `json:"suspicion,omitempty"`
+	SuspicionReasons map[string]int `json:"suspicion_reasons,omitempty"`
+	Flagged          bool           `json:"flagged,omitempty"`
+}
+
+// 관리자 API 게임 정보
+type AdminGameInfo struct {
+	Running    bool           `json:"running"`
+	TimeLeft   float64        `json:"time_left"`
+	Tick       uint64         `json:"tick"`
+	TeamScores map[string]int `json:"team_scores,omitempty"`
+}
+
+// 공지 요청
+type adminAnnouncementRequest struct {
+	Message   string               `json:"message"`
+	Severity  AnnouncementSeverity `json:"severity"`
+	Target    AnnouncementTarget   `json:"target"`
+	RoomID    string               `json:"room_id"`
+	ExpiresIn int                  `json:"expires_in"` // 초, 0이면 만료 없음
+}
+
+// 점검 모드 상태
+type adminMaintenanceState struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
+
+// 밸런스 리로드 요청
+// ApplyToRunning이 없으면 설정의 apply_to_running_games 사용
+type adminBalanceReloadRequest struct {
+	ApplyToRunning *bool `json:"apply_to_running"`
+}
+
+// 관리자 API 핸들러 생성
+// 모든 요청은 "Authorization: Bearer <token>" 헤더 필요
+// /admin/api/ 하위 경로에 등록
+func NewAdminHandler(server *Server, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/api/clients", server.serveAdminClients)
+	mux.HandleFunc("GET /admin/api/rooms", server.serveAdminRooms)
+	mux.HandleFunc("POST /admin/api/rooms/{id}/close", server.serveAdminCloseRoom)
+	mux.HandleFunc("POST /admin/api/clients/{id}/kick", server.serveAdminKickClient)
+	mux.HandleFunc("POST /admin/api/announcements", server.serveAdminAnnouncement)
+	mux.HandleFunc("GET /admin/api/maintenance", server.serveAdminGetMaintenance)
+	mux.HandleFunc("PUT /admin/api/maintenance", server.serveAdminSetMaintenance)
+	mux.HandleFunc("GET /admin/api/balance", server.serveAdminBalance)
+	mux.HandleFunc("POST /admin/api/balance/reload", server.serveAdminReloadBalance)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAdminAuthorized(r, token) {
+			server.logger.Warn("Unauthorized admin request", "remote_addr", r.RemoteAddr, "path", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func isAdminAuthorized(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+func writeAdminJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// GET /admin/api/clients
+func (s *Server) serveAdminClients(w http.ResponseWriter, r *http.Request) {
+	// client.room은 Room 고루틴이 바꾸므로 각 방의 멤버 목록에서 소속 방을 구함
+	// s.mutex와 r.mutex를 겹쳐 잡지 않도록 방 목록 스냅샷 후 따로 조회
+	roomOf := make(map[*Client]string)
+	for _, room := range s.roomSnapshot() {
+		room.mutex.RLock()
+		for client := range room.clients {
+			roomOf[client] = room.id
+		}
+		room.mutex.RUnlock()
+	}
+
+	// 리플레이 시청 상태는 s.mutex Lock 상태에서 변경됨, 프로필은 profileMutex로 읽음
+	s.mutex.RLock()
+	clients := make([]AdminClientInfo, 0, len(s.clients))
+	for _, client := range s.clients {
+		nickname, color, character := client.profile()
+		info := AdminClientInfo{
+			ID:        client.id,
+			Nickname:  nickname,
+			Color:     color,
+			Character: character,
+			RoomID:    roomOf[client],
+			Replay:    client.replay != nil,
+		}
+		if client.conn != nil {
+			info.RemoteAddr = client.conn.RemoteAddr().String()
+		}
+		clients = append(clients, info)
+	}
+	s.mutex.RUnlock()
+
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	writeAdminJSON(w, http.StatusOK, clients)
+}
+
+// 현재 방 목록 스냅샷
+func (s *Server) roomSnapshot() []*Room {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	rooms := make([]*Room, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// GET /admin/api/rooms
+func (s *Server) serveAdminRooms(w http.ResponseWriter, r *http.Request) {
+	rooms := s.roomSnapshot()
+
+	infos := make([]AdminRoomInfo, 0, len(rooms))
+	for _, room := range rooms {
+		infos = append(infos, room.adminInfo())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	writeAdminJSON(w, http.StatusOK, infos)
+}
+
+// 관리자 API용 방 상태 스냅샷
+// 게임은 game.mutex -> r.mutex 순서로 Lock을 잡으므로
+// 방 정보를 먼저 복사하고 r.mutex를 푼 뒤 게임 정보를 읽음
+func (r *Room) adminInfo() AdminRoomInfo {
+	r.mutex.RLock()
+	info := AdminRoomInfo{
+		ID:         r.id,
+		State:      r.state,
+		MaxPlayers: r.maxPlayers,
+		Settings:   r.settings,
+		Players:    make([]AdminPlayerInfo, 0, len(r.clients)),
+	}
+	if r.owner != nil {
+		info.OwnerID = r.owner.id
+	}
+	members := make([]*Client, 0, len(r.clients))
+	for client := range r.clients {
+		members = append(members, client)
+		info.Players = append(info.Players, AdminPlayerInfo{PlayerInfo: r.getPlayerInfo(client)})
+	}
+	game := r.game
+	r.mutex.RUnlock()
+
+	if game != nil {
+		game.mutex.RLock()
+		defer game.mutex.RUnlock()
+
+		gameInfo := &AdminGameInfo{
+			Running:    game.isRunning,
+			Tick:       game.tick,
+			TeamScores: game.teamScores(),
+		}
+		if game.isRunning {
+			gameInfo.TimeLeft = max(0, (game.duration - time.Since(game.startTime)).Seconds())
+		}
+		info.Game = gameInfo
+	}
+
+	for i, client := range members {
+		player := &info.Players[i]
+		if game != nil {
+			if ps, ok := game.players[client]; ok {
+				score, health := ps.Score, ps.Health
+				player.Score = &score
+				player.Health = &health
+				player.IsAlive = ps.IsAlive
+				player.IsConnected = ps.IsConnected
+				suspicion := math.Round(ps.antiCheat.score(time.Now())*100) / 100
+				player.Suspicion = &suspicion
+				player.SuspicionReasons = ps.antiCheat.reasonCounts()
+				player.Flagged = ps.antiCheat.flagged
+			}
+		}
+	}
+	sort.Slice(info.Players, func(i, j int) bool { return info.Players[i].ID < info.Players[j].ID })
+	return info
+}
+
+// POST /admin/api/rooms/{id}/close
+func (s *Server) serveAdminCloseRoom(w http.ResponseWriter, r *http.Request) {
+	roomID := r.PathValue("id")
+	s.mutex.RLock()
+	room, ok := s.rooms[roomID]
+	s.mutex.RUnlock()
+	if !ok {
+		http.Error(w, "room not found", http.StatusNotFound)
+		return
+	}
+
+	room.Close(adminRoomCloseReason)
+	s.logger.Info("Room closed by admin", "room_id", roomID, "remote_addr", r.RemoteAddr)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /admin/api/clients/{id}/kick
+func (s *Server) serveAdminKickClient(w http.ResponseWriter, r *http.Request) {
+	clientID := r.PathValue("id")
+	s.mutex.RLock()
+	client, ok := s.clients[clientID]
+	s.mutex.RUnlock()
+	if !ok {
+		http.Error(w, "client not found", http.StatusNotFound)
+		return
+	}
+
+	client.Disconnect(websocket.ClosePolicyViolation, adminKickCloseReason)
+	client.logger.Info("Client kicked by admin", "remote_addr", r.RemoteAddr)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /admin/api/announcements
+func (s *Server) serveAdminAnnouncement(w http.ResponseWriter, r *http.Request) {
+	var req adminAnnouncementRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sent, err := s.Announce(Announcement{
+		Message:  req.Message,
+		Severity: req.Severity,
+		Target:   req.Target,
+		RoomID:   req.RoomID,
+		TTL:      time.Duration(req.ExpiresIn) * time.Second,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.logger.Info("Announcement sent by admin", "recipients", sent, "remote_addr", r.RemoteAddr)
+	writeAdminJSON(w, http.StatusOK, map[string]int{"recipients": sent})
+}
+
+// GET /admin/api/maintenance
+func (s *Server) serveAdminGetMaintenance(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, adminMaintenanceState{Enabled: s.IsMaintenance(), Message: s.maintenanceMessage()})
+}
+
+// PUT /admin/api/maintenance
+func (s *Server) serveAdminSetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req adminMaintenanceState
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Message) > maxAnnouncementLen {
+		http.Error(w, "message is too long", http.StatusBadRequest)
+		return
+	}
+
+	s.SetMaintenance(req.Enabled, req.Message)
+	s.logger.Info("Maintenance mode set by admin", "enabled", req.Enabled, "remote_addr", r.RemoteAddr)
+	s.serveAdminGetMaintenance(w, r)
+}
+
+// GET /admin/api/balance
+func (s *Server) serveAdminBalance(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.currentBalance())
+}
+
+// POST /admin/api/balance/reload
+func (s *Server) serveAdminReloadBalance(w http.ResponseWriter, r *http.Request) {
+	if s.balance == nil || s.balance.path == "" {
+		http.Error(w, "no balance file configured", http.StatusConflict)
+		return
+	}
+
+	var req adminBalanceReloadRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	applyToRunning := s.balance.applyToRunning
+	if req.ApplyToRunning != nil {
+		applyToRunning = *req.ApplyToRunning
+	}
+
+	balance, err := s.ReloadBalance(applyToRunning)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	s.logger.Info("Balance reloaded by admin", "version", balance.Version, "apply_to_running", applyToRunning, "remote_addr", r.RemoteAddr)
+	writeAdminJSON(w, http.StatusOK, balance)
+}
@@ -0,0 +1,199 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	maxAnnouncementLen = 500
+
+	defaultMaintenanceMessage = "서버 점검 중입니다. 진행 중인 게임은 끝까지 할 수 있지만 새 게임은 시작할 수 없습니다."
+	maintenanceEndedMessage   = "서버 점검이 끝났습니다. 새 게임을 시작할 수 있습니다."
+	maintenanceEndedTTL       = 10 * time.Second
+)
+
+// 공지 중요도
+type AnnouncementSeverity string
+
+const (
+	AnnouncementInfo    AnnouncementSeverity = "info"
+	AnnouncementWarning AnnouncementSeverity = "warning"
+)
+
+// 공지 대상
+type AnnouncementTarget string
+
+const (
+	AnnouncementTargetAll   AnnouncementTarget = "all"   // 모든 접속자
+	AnnouncementTargetLobby AnnouncementTarget = "lobby" // 방에 없는 접속자
+	AnnouncementTargetRoom  AnnouncementTarget = "room"  // 특정 방 플레이어
+)
+
+// 서버 공지
+type Announcement struct {
+	Message  string
+	Severity AnnouncementSeverity
+	Target   AnnouncementTarget
+	RoomID   string        // Target이 room일 때
+	TTL      time.Duration // 0이면 만료 없음
+}
+
+// 기본값 채우기 및 검증
+func (a *Announcement) normalize() error {
+	a.Message = strings.TrimSpace(a.Message)
+	if a.Message == "" || len(a.Message) > maxAnnouncementLen {
+		return fmt.Errorf("message must be 1-%d bytes", maxAnnouncementLen)
+	}
+	switch a.Severity {
+	case "":
+		a.Severity = AnnouncementInfo
+	case AnnouncementInfo, AnnouncementWarning:
+	default:
+		return fmt.Errorf("unknown severity %q", a.Severity)
+	}
+	switch a.Target {
+	case "":
+		a.Target = AnnouncementTargetAll
+	case AnnouncementTargetAll, AnnouncementTargetLobby:
+	case AnnouncementTargetRoom:
+		if a.RoomID == "" {
+			return errors.New("room_id is required for room target")
+		}
+	default:
+		return fmt.Errorf("unknown target %q", a.Target)
+	}
+	if a.TTL < 0 {
+		return errors.New("expiry must not be negative")
+	}
+	return nil
+}
+
+func (a Announcement) payload() AnnouncementPayload {
+	payload := AnnouncementPayload{Message: a.Message, Severity: a.Severity}
+	if a.TTL > 0 {
+		payload.ExpiresAt = time.Now().Add(a.TTL).UnixMilli()
+	}
+	return payload
+}
+
+// 공지 전송
+// 전송된 클라이언트 수 반환
+func (s *Server) Announce(a Announcement) (int, error) {
+	if err := a.normalize(); err != nil {
+		return 0, err
+	}
+
+	msg := Message{Type: MessageTypeAnnouncement, Payload: a.payload()}
+	payloadBytes, err := json.Marshal(msg)
+	if err != nil {
+		metrics.marshalErrors.Add(1)
+		s.logger.Error("Failed to marshal announcement", "error", err)
+		return 0, err
+	}
+
+	// s.mutex와 room.mutex를 겹쳐 잡지 않도록 방은 스냅샷에서 찾은 뒤 따로 잠금
+	var recipients []*Client
+	if a.Target == AnnouncementTargetRoom {
+		var room *Room
+		for _, r := range s.roomSnapshot() {
+			if r.id == a.RoomID {
+				room = r
+				break
+			}
+		}
+		if room == nil {
+			return 0, fmt.Errorf("room %s not found", a.RoomID)
+		}
+		room.mutex.RLock()
+		for client := range room.clients {
+			if !client.isBot {
+				recipients = append(recipients, client)
+			}
+		}
+		room.mutex.RUnlock()
+	} else {
+		s.mutex.RLock()
+		recipients = make([]*Client, 0, len(s.clients))
+		for _, client := range s.clients {
+			if a.Target == AnnouncementTargetLobby && client.room != nil {
+				continue
+			}
+			recipients = append(recipients, client)
+		}
+		s.mutex.RUnlock()
+	}
+
+	sent := 0
+	for _, client := range recipients {
+		select {
+		case client.send <- payloadBytes:
+			sent++
+		default:
+			metrics.droppedSends.Inc("server")
+			client.logger.Warn("Send channel full, announcement not sent")
+		}
+	}
+	s.logger.Info("Announcement sent", "target", a.Target, "room_id", a.RoomID, "severity", a.Severity, "recipients", sent)
+	return sent, nil
+}
+
+// 점검 모드 여부
+func (s *Server) IsMaintenance() bool {
+	return s.maintenance.Load()
+}
+
+// 점검 모드 안내 문구
+func (s *Server) maintenanceMessage() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.maintenanceNotice == "" {
+		return defaultMaintenanceMessage
+	}
+	return s.maintenanceNotice
+}
+
+// 점검 모드 설정
+// 점검 중에는 방 생성, 방 참가, 게임 시작을 막고 진행 중인 게임은 그대로 진행
+// 상태가 바뀌면 모든 접속자에게 공지
+func (s *Server) SetMaintenance(enabled bool, message string) {
+	s.mutex.Lock()
+	s.maintenanceNotice = strings.TrimSpace(message)
+	s.mutex.Unlock()
+
+	if s.maintenance.Swap(enabled) == enabled {
+		return
+	}
+	s.logger.Info("Maintenance mode changed", "enabled", enabled)
+
+	if enabled {
+		s.Announce(Announcement{Message: s.maintenanceMessage(), Severity: AnnouncementWarning})
+	} else {
+		s.Announce(Announcement{Message: maintenanceEndedMessage, Severity: AnnouncementInfo, TTL: maintenanceEndedTTL})
+	}
+}
+
+// 점검 모드 전환 (시그널용)
+func (s *Server) ToggleMaintenance() {
+	s.SetMaintenance(!s.IsMaintenance(), "")
+}
+
+// 점검 중 새로 접속한 클라이언트에게 안내
+func (s *Server) sendMaintenanceNotice(client *Client) {
+	if !s.IsMaintenance() {
+		return
+	}
+	msg := Message{Type: MessageTypeAnnouncement, Payload: AnnouncementPayload{
+		Message:  s.maintenanceMessage(),
+		Severity: AnnouncementWarning,
+	}}
+	payloadBytes, _ := json.Marshal(msg)
+	select {
+	case client.send <- payloadBytes:
+	default:
+		metrics.droppedSends.Inc("server")
+	}
+}
@@ -0,0 +1,105 @@
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func newAnnouncementTestClient(id string) *Client {
+	return &Client{id: id, send: make(chan []byte, 4), logger: discardLogger}
+}
+
+func TestAnnounceTargets(t *testing.T) {
+	s := newDirectoryTestServer(nil, "node-a")
+	room := &Room{id: "ROOM01", clients: make(map[*Client]bool), maxPlayers: 4, state: RoomStatePlaying, logger: discardLogger}
+	s.rooms[room.id] = room
+
+	lobby := newAnnouncementTestClient("lobby")
+	player := newAnnouncementTestClient("player")
+	player.room = room
+	bot := newAnnouncementTestClient("bot")
+	bot.isBot = true
+	bot.room = room
+	room.clients[player] = true
+	room.clients[bot] = true
+	s.clients[lobby.id] = lobby
+	s.clients[player.id] = player
+
+	tests := []struct {
+		name    string
+		a       Announcement
+		want    []*Client
+		wantErr bool
+	}{
+		{"all", Announcement{Message: "hello"}, []*Client{lobby, player}, false},
+		{"lobby", Announcement{Message: "hello", Target: AnnouncementTargetLobby}, []*Client{lobby}, false},
+		{"room skips bots", Announcement{Message: "hello", Target: AnnouncementTargetRoom, RoomID: "ROOM01"}, []*Client{player}, false},
+		{"unknown room", Announcement{Message: "hello", Target: AnnouncementTargetRoom, RoomID: "NOPE01"}, nil, true},
+		{"empty message", Announcement{Message: "  "}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent, err := s.Announce(tt.a)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Announce err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if sent != len(tt.want) {
+				t.Fatalf("Announce sent = %d, want %d", sent, len(tt.want))
+			}
+			for _, client := range []*Client{lobby, player, bot} {
+				received := len(client.send)
+				for len(client.send) > 0 {
+					<-client.send
+				}
+				wantReceived := 0
+				for _, c := range tt.want {
+					if c == client {
+						wantReceived = 1
+					}
+				}
+				if received != wantReceived {
+					t.Fatalf("%s received %d announcements, want %d", client.id, received, wantReceived)
+				}
+			}
+		})
+	}
+}
+
+func TestMaintenanceBlocksNewRooms(t *testing.T) {
+	s := newDirectoryTestServer(nil, "node-a")
+	room := &Room{id: "ROOM01", clients: make(map[*Client]bool), maxPlayers: 4, state: RoomStateWaiting, logger: discardLogger}
+	s.rooms[room.id] = room
+	s.maintenance.Store(true)
+
+	tests := []struct {
+		name   string
+		handle func(client *Client)
+	}{
+		{"create room", func(client *Client) { s.handleCreateRoom(client) }},
+		{"join room", func(client *Client) {
+			s.handleJoinRoom(&Message{Type: MessageTypeJoinRoom, Payload: JoinRoomPayload{RoomID: room.id}, Sender: client})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newAnnouncementTestClient("c1")
+			tt.handle(client)
+
+			if len(client.send) != 1 {
+				t.Fatalf("got %d messages, want 1 error", len(client.send))
+			}
+			var msg Message
+			if err := json.Unmarshal(<-client.send, &msg); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if msg.Type != MessageTypeError {
+				t.Fatalf("message type = %s, want %s", msg.Type, MessageTypeError)
+			}
+			if len(s.rooms) != 1 || len(room.clients) != 0 || client.room != nil {
+				t.Fatal("client entered a room during maintenance")
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package backend
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// 공격 방향과 바라보는 방향의 허용 오차
+	clickFacingTolerance = math.Pi / 3
+	// 정규화된 방향 벡터 길이 허용 오차 (클라이언트가 정규화해서 보냄)
+	directionMagnitudeTolerance = 0.05
+	// 클릭 시 클라이언트가 보낸 위치와 서버 위치 허용 오차 (지연 고려)
+	clickPositionTolerance = 5.0
+
+	// 회전 속도 제한 (토큰 버킷, 라디안)
+	// 마우스가 캐릭터를 가로지르면 한 번에 반 바퀴 돌 수 있으므로 한 바퀴까지는 즉시 허용
+	maxYawRate  = 8 * math.Pi
+	maxYawBurst = 2 * math.Pi
+
+	// 의심 점수는 초당 이만큼 줄어듦
+	suspicionDecayPerSecond = 0.05
+	// 이 점수를 넘으면 경고 로그
+	suspicionFlagThreshold = 10.0
+)
+
+// 의심 사유
+type suspicionReason string
+
+const (
+	suspicionInvalidDirection suspicionReason = "invalid_direction" // 길이가 1이 아니거나 0인 방향
+	suspicionFacingMismatch   suspicionReason = "facing_mismatch"   // 바라보는 방향과 다른 공격
+	suspicionYawRate          suspicionReason = "yaw_rate"          // 회전 속도 초과
+	suspicionPositionMismatch suspicionReason = "position_mismatch" // 서버 위치와 다른 공격 위치
+)
+
+// 사유별 점수
+var suspicionWeights = map[suspicionReason]float64{
+	suspicionInvalidDirection: 2,
+	suspicionFacingMismatch:   1,
+	suspicionYawRate:          0.5,
+	suspicionPositionMismatch: 1,
+}
+
+// 플레이어별 입력 검증 상태
+// g.mutex Lock 상태에서만 접근
+type antiCheatState struct {
+	lookYaw   float64 // 마지막으로 받은 look (회전 제한 적용 후), 공격 중에도 갱신
+	yawBudget float64
+	lastLook  time.Time
+
+	suspicion float64
+	updatedAt time.Time
+	reasons   map[suspicionReason]int
+	flagged   bool
+}
+
+func newAntiCheatState(yaw float64, now time.Time) antiCheatState {
+	return antiCheatState{
+		lookYaw:   yaw,
+		yawBudget: maxYawBurst,
+		lastLook:  now,
+		updatedAt: now,
+		reasons:   make(map[suspicionReason]int),
+	}
+}
+
+// 현재 의심 점수 (시간 경과로 감소)
+func (a *antiCheatState) score(now time.Time) float64 {
+	elapsed := now.Sub(a.updatedAt).Seconds()
+	return math.Max(0, a.suspicion-elapsed*suspicionDecayPerSecond)
+}
+
+// 사유별 누적 횟수 복사본
+func (a *antiCheatState) reasonCounts() map[string]int {
+	if len(a.reasons) == 0 {
+		return nil
+	}
+	counts := make(map[string]int, len(a.reasons))
+	for reason, count := range a.reasons {
+		counts[string(reason)] = count
+	}
+	return counts
+}
+
+// 의심 입력 기록
+// g.mutex Lock 상태에서 호출
+func (g *Game) flagSuspicious(ps *PlayerState, reason suspicionReason) {
+	now := time.Now()
+	a := &ps.antiCheat
+	a.suspicion = a.score(now) + suspicionWeights[reason]
+	a.updatedAt = now
+	a.reasons[reason]++
+	metrics.suspiciousInputs.Inc(string(reason))
+
+	if a.suspicion >= suspicionFlagThreshold && !a.flagged {
+		a.flagged = true
+		g.logger.Warn("Player flagged for suspicious input", "client_id", ps.ID, "nickname", ps.Nickname, "suspicion", a.suspicion, "reasons", a.reasonCounts())
+	} else if a.suspicion < suspicionFlagThreshold/2 {
+		a.flagged = false
+	}
+}
+
+// -π ~ π 범위로 정규화
+func normalizeAngle(angle float64) float64 {
+	return math.Remainder(angle, 2*math.Pi)
+}
+
+// 회전 속도 제한
+// 허용량을 넘는 회전은 잘라내고 의심 기록, 적용할 yaw 반환
+// g.mutex Lock 상태에서 호출
+func (g *Game) limitYawChange(ps *PlayerState, requested float64) float64 {
+	now := time.Now()
+	a := &ps.antiCheat
+	a.yawBudget = math.Min(maxYawBurst, a.yawBudget+now.Sub(a.lastLook).Seconds()*maxYawRate)
+	a.lastLook = now
+
+	diff := normalizeAngle(requested - a.lookYaw)
+	if math.Abs(diff) > a.yawBudget {
+		g.flagSuspicious(ps, suspicionYawRate)
+		diff = math.Copysign(a.yawBudget, diff)
+	}
+	a.yawBudget -= math.Abs(diff)
+	a.lookYaw = normalizeAngle(a.lookYaw + diff)
+	return a.lookYaw
+}
+
+// 공격 방향 검증
+// 정규화된 방향 반환, 사용할 수 없는 입력이면 ok false
+// g.mutex Lock 상태에서 호출
+func (g *Game) validateAttackDirection(ps *PlayerState, dirX, dirZ float64, actionData map[string]interface{}) (float64, float64, bool) {
+	magnitude := math.Hypot(dirX, dirZ)
+	if math.IsNaN(magnitude) || math.IsInf(magnitude, 0) || magnitude < 1e-6 {
+		g.flagSuspicious(ps, suspicionInvalidDirection)
+		return 0, 0, false
+	}
+	if math.Abs(magnitude-1) > directionMagnitudeTolerance {
+		// 길이를 늘려 사거리를 늘리려는 시도
+		g.flagSuspicious(ps, suspicionInvalidDirection)
+	}
+	dirX /= magnitude
+	dirZ /= magnitude
+
+	// 바라보는 방향 (sin yaw, cos yaw)과 비교
+	facingX, facingZ := math.Sin(ps.antiCheat.lookYaw), math.Cos(ps.antiCheat.lookYaw)
+	dot := math.Max(-1, math.Min(1, dirX*facingX+dirZ*facingZ))
+	if math.Acos(dot) > clickFacingTolerance {
+		// 바라보는 방향으로 공격
+		g.flagSuspicious(ps, suspicionFacingMismatch)
+		dirX, dirZ = facingX, facingZ
+	}
+
+	// 클라이언트가 보낸 공격 위치 확인 (판정은 항상 서버 위치 사용)
+	if start, ok := actionData["start_position"].(map[string]interface{}); ok {
+		startX, okX := start["x"].(float64)
+		startZ, okZ := start["z"].(float64)
+		if okX && okZ && math.Hypot(startX-ps.X, startZ-ps.Z) > clickPositionTolerance {
+			g.flagSuspicious(ps, suspicionPositionMismatch)
+		}
+	}
+
+	return dirX, dirZ, true
+}
@@ -0,0 +1,189 @@
+package backend
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// 입력 검증 테스트용 게임과 플레이어
+func newAntiCheatTestGame(t *testing.T) (*Game, *Client, *PlayerState) {
+	t.Helper()
+	useTestMetrics(t)
+
+	now := time.Now()
+	client := &Client{id: "p1"}
+	ps := newTestPlayer("p1", 0, 0, maxPlayerHealth)
+	ps.Stats = characterOrDefault(defaultCharacter).Stats
+	ps.antiCheat = newAntiCheatState(0, now)
+
+	g := newTestGame(now)
+	g.players[client] = ps
+	g.hammerAttacks = make(map[string]*HammerAttack)
+	g.isReady = true
+	return g, client, ps
+}
+
+func sendAction(g *Game, client *Client, actionType string, data map[string]interface{}) {
+	g.HandlePlayerAction(&Message{Sender: client, Payload: map[string]interface{}{"action_type": actionType, "data": data}})
+}
+
+func TestMoveSpeedIsServerAuthoritative(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  map[string]interface{}
+		moves bool
+	}{
+		{"forward at max speed", map[string]interface{}{"forward": 1.0}, true},
+		{"diagonal at max speed", map[string]interface{}{"forward": 1.0, "left": 1.0}, true},
+		{"oversized input", map[string]interface{}{"forward": 50.0, "left": 50.0}, false},
+		{"teleport fields", map[string]interface{}{"forward": 1.0, "x": 12.0, "z": 12.0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, client, ps := newAntiCheatTestGame(t)
+			sendAction(g, client, "move", tt.data)
+
+			maxStep := g.playerSpeed(ps)
+			for tick := 0; tick < 5; tick++ {
+				x, z := ps.X, ps.Z
+				g.updateGameState()
+				if step := math.Hypot(ps.X-x, ps.Z-z); step > maxStep+1e-9 {
+					t.Fatalf("tick %d moved %.3f, max %.3f", tick, step, maxStep)
+				}
+			}
+			// 이동량은 서버 속도로만 결정 (클라이언트 위치 무시)
+			if moved := math.Hypot(ps.X, ps.Z); tt.moves != (moved > 0) || moved > 5*maxStep+1e-9 {
+				t.Fatalf("moved %.3f in 5 ticks, moves=%v, max %.3f", moved, tt.moves, 5*maxStep)
+			}
+			if ps.antiCheat.suspicion != 0 {
+				t.Fatalf("movement input raised suspicion %.2f", ps.antiCheat.suspicion)
+			}
+		})
+	}
+}
+
+func TestValidateAttackDirection(t *testing.T) {
+	tests := []struct {
+		name       string
+		yaw        float64
+		dirX, dirZ float64
+		start      map[string]interface{}
+		wantOK     bool
+		wantX      float64
+		wantZ      float64
+		wantReason suspicionReason // 비어있으면 의심 기록 없음
+	}{
+		{"facing direction", 0, 0, 1, nil, true, 0, 1, ""},
+		{"within tolerance", 0, math.Sin(clickFacingTolerance / 2), math.Cos(clickFacingTolerance / 2), nil, true, math.Sin(clickFacingTolerance / 2), math.Cos(clickFacingTolerance / 2), ""},
+		{"slightly unnormalized", math.Pi / 2, 1.02, 0, nil, true, 1, 0, ""},
+		{"attack behind", 0, 0, -1, nil, true, 0, 1, suspicionFacingMismatch},
+		{"oversized vector", 0, 0, 3, nil, true, 0, 1, suspicionInvalidDirection},
+		{"zero vector", 0, 0, 0, nil, false, 0, 0, suspicionInvalidDirection},
+		{"nan vector", 0, math.NaN(), 1, nil, false, 0, 0, suspicionInvalidDirection},
+		{"start near server position", 0, 0, 1, map[string]interface{}{"x": 1.0, "z": 1.0}, true, 0, 1, ""},
+		{"start far from server position", 0, 0, 1, map[string]interface{}{"x": 20.0, "z": 0.0}, true, 0, 1, suspicionPositionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, ps := newAntiCheatTestGame(t)
+			ps.antiCheat.lookYaw = tt.yaw
+			data := map[string]interface{}{}
+			if tt.start != nil {
+				data["start_position"] = tt.start
+			}
+
+			x, z, ok := g.validateAttackDirection(ps, tt.dirX, tt.dirZ, data)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (math.Abs(x-tt.wantX) > 1e-9 || math.Abs(z-tt.wantZ) > 1e-9) {
+				t.Fatalf("direction = (%.3f, %.3f), want (%.3f, %.3f)", x, z, tt.wantX, tt.wantZ)
+			}
+
+			reasons := ps.antiCheat.reasonCounts()
+			if tt.wantReason == "" {
+				if len(reasons) != 0 {
+					t.Fatalf("legitimate attack flagged: %v", reasons)
+				}
+				return
+			}
+			if reasons[string(tt.wantReason)] != 1 || len(reasons) != 1 {
+				t.Fatalf("reasons = %v, want only %s", reasons, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestAttackCadenceFollowsCooldown(t *testing.T) {
+	g, client, ps := newAntiCheatTestGame(t)
+	click := map[string]interface{}{"direction": map[string]interface{}{"x": 0.0, "z": 1.0}}
+
+	// 쿨타임 안에 연타하면 첫 공격만 적용
+	for i := 0; i < 5; i++ {
+		sendAction(g, client, "click", click)
+	}
+	if len(g.hammerAttacks) != 1 || ps.Combat.Swings != 1 {
+		t.Fatalf("rapid clicks made %d attacks (%d swings), want 1", len(g.hammerAttacks), ps.Combat.Swings)
+	}
+
+	// 쿨타임이 지나면 다시 공격 가능
+	ps.LastAttackTime = time.Now().Add(-g.hammerCooldown(ps))
+	sendAction(g, client, "click", click)
+	if ps.Combat.Swings != 2 {
+		t.Fatalf("click after cooldown made %d swings, want 2", ps.Combat.Swings)
+	}
+}
+
+func TestYawRateLimit(t *testing.T) {
+	g, _, ps := newAntiCheatTestGame(t)
+
+	// 한 번의 반 바퀴 회전은 허용
+	if got := g.limitYawChange(ps, math.Pi/2); math.Abs(got-math.Pi/2) > 1e-9 {
+		t.Fatalf("yaw = %.3f, want %.3f", got, math.Pi/2)
+	}
+	if len(ps.antiCheat.reasonCounts()) != 0 {
+		t.Fatal("legitimate turn flagged")
+	}
+
+	// 같은 순간에 반 바퀴씩 계속 돌면 허용량을 넘어 잘리고 의심 기록
+	for i := 0; i < 4; i++ {
+		g.limitYawChange(ps, ps.antiCheat.lookYaw+math.Pi*0.99)
+	}
+	if ps.antiCheat.reasonCounts()[string(suspicionYawRate)] == 0 {
+		t.Fatal("rapid spinning was not flagged")
+	}
+	if ps.antiCheat.yawBudget < 0 {
+		t.Fatalf("yaw budget went negative: %.3f", ps.antiCheat.yawBudget)
+	}
+}
+
+func TestSuspicionFlagAndDecay(t *testing.T) {
+	g, _, ps := newAntiCheatTestGame(t)
+	weight := suspicionWeights[suspicionInvalidDirection]
+	needed := int(math.Ceil(suspicionFlagThreshold / weight))
+
+	for i := 0; i < needed-1; i++ {
+		g.flagSuspicious(ps, suspicionInvalidDirection)
+	}
+	if ps.antiCheat.flagged {
+		t.Fatal("flagged before reaching the threshold")
+	}
+	// 호출 사이에도 점수가 조금씩 줄어들므로 한 번 더 기록
+	g.flagSuspicious(ps, suspicionInvalidDirection)
+	g.flagSuspicious(ps, suspicionInvalidDirection)
+	if !ps.antiCheat.flagged {
+		t.Fatalf("not flagged at suspicion %.2f", ps.antiCheat.suspicion)
+	}
+
+	// 시간이 지나면 점수 감소
+	later := ps.antiCheat.updatedAt.Add(10 * time.Second)
+	if got, want := ps.antiCheat.score(later), ps.antiCheat.suspicion-10*suspicionDecayPerSecond; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("score after 10s = %.3f, want %.3f", got, want)
+	}
+	if got := ps.antiCheat.score(later.Add(time.Hour)); got != 0 {
+		t.Fatalf("score after an hour = %.3f, want 0", got)
+	}
+}
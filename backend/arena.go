@@ -0,0 +1,219 @@
+package backend
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// 경기장 축소
+	arenaShrinkStart    = 0.3                     // 경기 시간 중 이 비율이 지나면 축소 시작
+	arenaShrinkEnd      = 0.85                    // 이 비율에서 최소 크기 도달
+	arenaMinBoundary    = 6.0                     // 최소 안전 구역 경계
+	arenaDamage         = 1                       // 안전 구역 밖 피해
+	arenaDamageInterval = 1000 * time.Millisecond // 안전 구역 밖 피해 주기
+
+	// 낙하물
+	hazardType        = "falling_crate"
+	hazardFirstDelay  = 10 * time.Second // 경기 시작 후 첫 낙하물까지 시간
+	hazardMinInterval = 4 * time.Second  // 낙하물 생성 간격
+	hazardMaxInterval = 7 * time.Second
+	hazardWarning     = 1500 * time.Millisecond // 낙하 지점 표시부터 충돌까지 시간
+	hazardLinger      = 500 * time.Millisecond  // 충돌 후 표시 유지 시간 (클라이언트 효과용)
+	hazardRadius      = 2.5                     // 충돌 범위
+	hazardTargetRatio = 0.5                     // 플레이어 위치를 노리는 확률
+)
+
+// 경기장 축소 상태
+// 원점 기준 정사각형, 경계 밖에 있으면 주기적으로 피해
+type ArenaState struct {
+	Boundary      float64   `json:"boundary"`       // 현재 안전 구역 경계
+	FinalBoundary float64   `json:"final_boundary"` // 최종 안전 구역 경계
+	ShrinkStartAt time.Time `json:"shrink_start_at"`
+	ShrinkEndAt   time.Time `json:"shrink_end_at"`
+}
+
+// 낙하물
+// 낙하 지점을 미리 표시하고 ImpactAt에 범위 안의 플레이어에게 피해
+type Hazard struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	X         float64   `json:"x"`
+	Z         float64   `json:"z"`
+	Radius    float64   `json:"radius"`
+	CreatedAt time.Time `json:"created_at"`
+	ImpactAt  time.Time `json:"impact_at"`
+	Impacted  bool      `json:"impacted"`
+}
+
+// 경기장 축소, 낙하물 처리
+// 카운트 다운 중에는 동작하지 않음
+// g.mutex Lock 상태에서 호출
+func (g *Game) updateArena() {
+	if !g.isRunning {
+		return
+	}
+	now := time.Now()
+
+	if g.settings.ShrinkingArena {
+		g.updateArenaBoundary(now)
+	}
+	if g.settings.Hazards {
+		g.updateHazards(now)
+	}
+}
+
+// 안전 구역 경계 갱신 및 밖에 있는 플레이어 피해
+func (g *Game) updateArenaBoundary(now time.Time) {
+	if g.arena == nil {
+		g.arena = &ArenaState{
+			Boundary:      mapBoundary,
+			FinalBoundary: arenaMinBoundary,
+			ShrinkStartAt: g.startTime.Add(time.Duration(float64(g.duration) * arenaShrinkStart)),
+			ShrinkEndAt:   g.startTime.Add(time.Duration(float64(g.duration) * arenaShrinkEnd)),
+		}
+	}
+
+	arena := g.arena
+	if now.After(arena.ShrinkStartAt) {
+		progress := math.Min(1, float64(now.Sub(arena.ShrinkStartAt))/float64(arena.ShrinkEndAt.Sub(arena.ShrinkStartAt)))
+		arena.Boundary = mapBoundary - (mapBoundary-arena.FinalBoundary)*progress
+	}
+
+	for _, ps := range g.players {
+		if !ps.IsConnected || !ps.IsAlive || g.isInSafeZone(ps.X, ps.Z) {
+			continue
+		}
+		if now.Sub(ps.LastArenaDamage) < arenaDamageInterval {
+			continue
+		}
+		// 자기 위치에서 들어오는 피해라 막기 불가
+		// 환경 피해라 피격 무적과 상관없이 arenaDamageInterval마다 들어감
+		if g.damagePlayer(nil, ps, damageInfo{amount: arenaDamage, sourceX: ps.X, sourceZ: ps.Z, source: damageSourceArena, environmental: true}, now) {
+			ps.LastArenaDamage = now
+		}
+	}
+}
+
+// 안전 구역 안인지
+// 경기장 축소를 안 쓰거나 아직 시작 전이면 항상 true
+func (g *Game) isInSafeZone(x, z float64) bool {
+	if g.arena == nil {
+		return true
+	}
+	return math.Abs(x) <= g.arena.Boundary && math.Abs(z) <= g.arena.Boundary
+}
+
+// 현재 안전 구역 경계
+func (g *Game) safeBoundary() float64 {
+	if g.arena == nil {
+		return mapBoundary
+	}
+	return g.arena.Boundary
+}
+
+// 낙하물 생성, 충돌, 제거
+func (g *Game) updateHazards(now time.Time) {
+	if g.nextHazardAt.IsZero() {
+		g.nextHazardAt = g.startTime.Add(hazardFirstDelay)
+	}
+	if now.After(g.nextHazardAt) {
+		g.spawnHazard(now)
+		g.nextHazardAt = now.Add(hazardMinInterval + time.Duration(rand.Int63n(int64(hazardMaxInterval-hazardMinInterval))))
+	}
+
+	for id, hazard := range g.hazards {
+		if hazard.Impacted {
+			if now.Sub(hazard.ImpactAt) >= hazardLinger {
+				delete(g.hazards, id)
+			}
+			continue
+		}
+		if now.Before(hazard.ImpactAt) {
+			continue
+		}
+
+		hazard.Impacted = true
+		// 위에서 떨어지므로 막기를 깸, 피격 무적 중에도 맞음
+		damage := damageInfo{amount: g.balance.HammerDamage, sourceX: hazard.X, sourceZ: hazard.Z, breaksBlock: true, source: hazard.Type, environmental: true}
+		for _, ps := range g.players {
+			if math.Hypot(ps.X-hazard.X, ps.Z-hazard.Z) <= hazard.Radius {
+				g.damagePlayer(nil, ps, damage, now)
+			}
+		}
+		g.logger.Debug("Hazard impact", "hazard_id", hazard.ID, "x", hazard.X, "z", hazard.Z)
+	}
+}
+
+// 낙하물 생성
+// 일정 확률로 살아있는 플레이어 위치를 노리고, 아니면 안전 구역 안 무작위 위치
+func (g *Game) spawnHazard(now time.Time) {
+	limit := math.Max(0, g.safeBoundary()-hazardRadius)
+	x := (rand.Float64()*2 - 1) * limit
+	z := (rand.Float64()*2 - 1) * limit
+
+	if rand.Float64() < hazardTargetRatio {
+		targets := make([]*PlayerState, 0, len(g.players))
+		for _, ps := range g.players {
+			if ps.IsConnected && ps.IsAlive {
+				targets = append(targets, ps)
+			}
+		}
+		if len(targets) > 0 {
+			target := targets[rand.Intn(len(targets))]
+			x, z = target.X, target.Z
+		}
+	}
+
+	g.hazardCounter++
+	hazard := &Hazard{
+		ID:        fmt.Sprintf("hazard_%d", g.hazardCounter),
+		Type:      hazardType,
+		X:         x,
+		Z:         z,
+		Radius:    hazardRadius,
+		CreatedAt: now,
+		ImpactAt:  now.Add(hazardWarning),
+	}
+	g.hazards[hazard.ID] = hazard
+	g.logger.Debug("Hazard spawned", "hazard_id", hazard.ID, "x", x, "z", z)
+}
+
+// 현재 낙하물 목록
+// g.mutex RLock 이상 상태에서 호출
+func (g *Game) activeHazards() []*Hazard {
+	if len(g.hazards) == 0 {
+		return nil
+	}
+	hazards := make([]*Hazard, 0, len(g.hazards))
+	for _, hazard := range g.hazards {
+		hazards = append(hazards, hazard)
+	}
+	return hazards
+}
+
+// 피해야 할 위험이 있으면 벗어날 방향
+// 안전 구역 밖이면 중앙으로, 낙하 예정 지점 안이면 중심 반대쪽으로
+// 봇 이동 판단에 사용
+func (g *Game) dangerEscape(ps *PlayerState) (float64, float64, bool) {
+	if !g.isInSafeZone(ps.X, ps.Z) {
+		return -ps.X, -ps.Z, true
+	}
+	for _, hazard := range g.hazards {
+		if hazard.Impacted {
+			continue
+		}
+		dx, dz := ps.X-hazard.X, ps.Z-hazard.Z
+		if math.Hypot(dx, dz) > hazard.Radius {
+			continue
+		}
+		// 정중앙이면 아무 방향으로
+		if math.Hypot(dx, dz) < 1e-6 {
+			return 1, 0, true
+		}
+		return dx, dz, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,114 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+// 테스트용 게임 (틱 루프 없이 직접 호출)
+func newTestGame(now time.Time, players ...*PlayerState) *Game {
+	g := &Game{
+		players:   make(map[*Client]*PlayerState),
+		hazards:   make(map[string]*Hazard),
+		balance:   DefaultBalance(),
+		isRunning: true,
+		startTime: now,
+		duration:  time.Minute,
+		logger:    discardLogger,
+	}
+	for _, ps := range players {
+		g.players[&Client{id: ps.ID}] = ps
+	}
+	return g
+}
+
+func newTestPlayer(id string, x, z float64, health int) *PlayerState {
+	return &PlayerState{ID: id, X: x, Z: z, Health: health, MaxHealth: health, IsAlive: true, IsConnected: true}
+}
+
+func TestArenaDamageCadence(t *testing.T) {
+	start := time.Unix(1000, 0)
+	outside := newTestPlayer("outside", 9, 0, 10)
+	inside := newTestPlayer("inside", 1, 1, 10)
+	g := newTestGame(start, outside, inside)
+	// 이미 최소 크기로 줄어든 경기장
+	g.arena = &ArenaState{Boundary: arenaMinBoundary, FinalBoundary: arenaMinBoundary, ShrinkStartAt: start, ShrinkEndAt: start}
+
+	// 100ms 틱으로 4.95초 진행
+	const step = 100 * time.Millisecond
+	var hitTimes []time.Duration
+	for elapsed := time.Duration(0); elapsed < 5*time.Second; elapsed += step {
+		before := outside.Health
+		g.updateArenaBoundary(start.Add(elapsed))
+		if outside.Health < before {
+			hitTimes = append(hitTimes, elapsed)
+		}
+		if outside.IsInvincible {
+			t.Fatalf("arena damage granted hit invincibility at %v", elapsed)
+		}
+	}
+
+	want := []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}
+	if len(hitTimes) != len(want) {
+		t.Fatalf("arena damage at %v, want every %v: %v", hitTimes, arenaDamageInterval, want)
+	}
+	for i := range want {
+		if hitTimes[i] != want[i] {
+			t.Fatalf("arena damage at %v, want %v", hitTimes, want)
+		}
+	}
+	if inside.Health != 10 {
+		t.Fatalf("player inside the safe zone took damage: health %d", inside.Health)
+	}
+}
+
+func TestArenaDamageIgnoresHitInvincibility(t *testing.T) {
+	start := time.Unix(1000, 0)
+	ps := newTestPlayer("p1", 9, 0, 10)
+	g := newTestGame(start, ps)
+	g.arena = &ArenaState{Boundary: arenaMinBoundary, FinalBoundary: arenaMinBoundary, ShrinkStartAt: start, ShrinkEndAt: start}
+
+	// 방금 다른 플레이어에게 맞아 피격 무적 상태
+	ps.IsInvincible = true
+	ps.InvincibleUntil = start.Add(time.Duration(g.balance.InvincibleDuration))
+
+	g.updateArenaBoundary(start)
+	if ps.Health != 10-arenaDamage {
+		t.Fatalf("health = %d, want %d", ps.Health, 10-arenaDamage)
+	}
+	if !ps.InvincibleUntil.Equal(start.Add(time.Duration(g.balance.InvincibleDuration))) {
+		t.Fatal("arena damage changed the invincibility window")
+	}
+}
+
+func TestHazardImpactDuringHitInvincibility(t *testing.T) {
+	start := time.Unix(1000, 0)
+	target := newTestPlayer("target", 2, 2, 10)
+	bystander := newTestPlayer("bystander", 2+hazardRadius+1, 2, 10)
+	g := newTestGame(start, target, bystander)
+	g.nextHazardAt = start.Add(time.Hour) // 새 낙하물 생성 안 함
+
+	target.IsInvincible = true
+	target.InvincibleUntil = start.Add(time.Second)
+	g.hazards["h1"] = &Hazard{ID: "h1", Type: hazardType, X: 2, Z: 2, Radius: hazardRadius, CreatedAt: start, ImpactAt: start.Add(hazardWarning)}
+
+	// 충돌 전에는 피해 없음
+	g.updateHazards(start.Add(hazardWarning - time.Millisecond))
+	if target.Health != 10 {
+		t.Fatalf("hazard hit before impact: health %d", target.Health)
+	}
+
+	g.updateHazards(start.Add(hazardWarning))
+	if want := 10 - g.balance.HammerDamage; target.Health != want {
+		t.Fatalf("target health = %d, want %d", target.Health, want)
+	}
+	if bystander.Health != 10 {
+		t.Fatalf("player outside the impact radius took damage: health %d", bystander.Health)
+	}
+
+	// 한 번만 충돌
+	g.updateHazards(start.Add(hazardWarning + 100*time.Millisecond))
+	if want := 10 - g.balance.HammerDamage; target.Health != want {
+		t.Fatalf("hazard hit twice: health %d, want %d", target.Health, want)
+	}
+}
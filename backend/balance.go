@@ -0,0 +1,273 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultBalanceVersion        = "default"
+	defaultBalanceReloadInterval = 5 * time.Second
+)
+
+// 게임 밸런스 수치
+// 버전은 game_init_data와 리플레이 정보에 기록되어 어떤 수치로 진행된 게임인지 확인 가능
+type Balance struct {
+	Version            string   `json:"version"`
+	PlayerSpeed        float64  `json:"player_speed"`        // 틱당 이동 거리
+	HammerRange        float64  `json:"hammer_range"`        // 망치 공격 범위
+	HammerDamage       int      `json:"hammer_damage"`       // 망치 한 번 데미지
+	HammerCooldown     Duration `json:"hammer_cooldown"`     // 망치 공격 쿨타임
+	InvincibleDuration Duration `json:"invincible_duration"` // 피격, 부활 후 무적 시간
+}
+
+// 기본 밸런스
+func DefaultBalance() *Balance {
+	return &Balance{
+		Version:            defaultBalanceVersion,
+		PlayerSpeed:        playerSpeed,
+		HammerRange:        hammerRange,
+		HammerDamage:       hammerDamage,
+		HammerCooldown:     Duration(hammerCooldown),
+		InvincibleDuration: Duration(invincibleDuration),
+	}
+}
+
+// 밸런스 검증
+func (b *Balance) Validate() error {
+	var errs []error
+	if b.Version == "" {
+		errs = append(errs, errors.New("version is required"))
+	}
+	if b.PlayerSpeed <= 0 || b.PlayerSpeed > 5 {
+		errs = append(errs, errors.New("player_speed must be in (0, 5]"))
+	}
+	if b.HammerRange <= 0 || b.HammerRange > mapBoundary {
+		errs = append(errs, fmt.Errorf("hammer_range must be in (0, %g]", mapBoundary))
+	}
+	if b.HammerDamage < 1 {
+		errs = append(errs, errors.New("hammer_damage must be at least 1"))
+	}
+	if b.HammerCooldown < Duration(gameTickRate) {
+		errs = append(errs, fmt.Errorf("hammer_cooldown must be at least one tick (%s)", gameTickRate))
+	}
+	if b.InvincibleDuration < 0 {
+		errs = append(errs, errors.New("invincible_duration must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// 밸런스 파일 불러오기
+// 파일에 없는 항목은 기본값 사용
+func loadBalanceFile(path string) (*Balance, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	balance := DefaultBalance()
+	balance.Version = ""
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(balance); err != nil {
+		return nil, fmt.Errorf("parse balance %s: %w", path, err)
+	}
+	if err := balance.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid balance %s: %w", path, err)
+	}
+	return balance, nil
+}
+
+// 밸런스 저장소
+// 현재 밸런스는 교체만 하고 수정하지 않으므로 게임은 받은 포인터를 그대로 읽어도 안전
+type BalanceStore struct {
+	path           string // 비어있으면 기본 밸런스 고정
+	applyToRunning bool   // 리로드 시 진행 중인 게임에도 적용
+	current        atomic.Pointer[Balance]
+	logger         *slog.Logger
+
+	mutex   sync.Mutex // 리로드 직렬화
+	modTime time.Time  // 마지막으로 불러온 파일 수정 시각
+}
+
+// 밸런스 저장소 생성
+// path가 비어있으면 기본 밸런스만 사용
+func NewBalanceStore(path string, applyToRunning bool, logger *slog.Logger) (*BalanceStore, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	store := &BalanceStore{path: path, applyToRunning: applyToRunning, logger: logger}
+	store.current.Store(DefaultBalance())
+	if path == "" {
+		return store, nil
+	}
+	if _, err := store.Reload(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// 현재 밸런스
+func (s *BalanceStore) Current() *Balance {
+	return s.current.Load()
+}
+
+// 밸런스 파일 다시 불러오기
+// 실패하면 기존 밸런스 유지, 버전이 바뀌었으면 true
+func (s *BalanceStore) Reload() (bool, error) {
+	if s.path == "" {
+		return false, errors.New("no balance file configured")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return false, err
+	}
+	balance, err := loadBalanceFile(s.path)
+	if err != nil {
+		return false, err
+	}
+	s.modTime = info.ModTime()
+
+	previous := s.current.Swap(balance)
+	changed := previous.Version != balance.Version
+	if changed {
+		s.logger.Info("Balance loaded", "version", balance.Version, "previous_version", previous.Version)
+	} else if *previous != *balance {
+		s.logger.Warn("Balance values changed without a version bump", "version", balance.Version)
+	}
+	return changed, nil
+}
+
+// 파일이 바뀌었는지 확인
+func (s *BalanceStore) fileChanged() bool {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return false
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return !info.ModTime().Equal(s.modTime)
+}
+
+// 서버 밸런스 저장소 설정
+func (s *Server) SetBalanceStore(store *BalanceStore) {
+	s.balance = store
+}
+
+// 새 게임에 적용할 밸런스
+func (s *Server) currentBalance() *Balance {
+	if s.balance == nil {
+		return DefaultBalance()
+	}
+	return s.balance.Current()
+}
+
+// 밸런스 리로드
+// 새 게임에는 바로 적용, applyToRunning이면 진행 중인 게임에도 다음 틱부터 적용
+func (s *Server) ReloadBalance(applyToRunning bool) (*Balance, error) {
+	if s.balance == nil {
+		return nil, errors.New("balance reloading is not configured")
+	}
+	if _, err := s.balance.Reload(); err != nil {
+		s.logger.Error("Balance reload failed, keeping the current balance", "error", err)
+		return s.balance.Current(), err
+	}
+
+	balance := s.balance.Current()
+	if applyToRunning {
+		s.applyBalanceToRunningGames(balance)
+	}
+	return balance, nil
+}
+
+// 진행 중인 모든 게임에 밸런스 적용 예약
+func (s *Server) applyBalanceToRunningGames(balance *Balance) {
+	s.mutex.RLock()
+	rooms := make([]*Room, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+	s.mutex.RUnlock()
+
+	for _, room := range rooms {
+		room.mutex.RLock()
+		game := room.game
+		room.mutex.RUnlock()
+		if game != nil {
+			game.setPendingBalance(balance)
+		}
+	}
+}
+
+// 밸런스 파일 변경 감시
+// stop이 닫힐 때까지 interval마다 수정 시각 확인
+func (s *Server) WatchBalance(interval time.Duration, stop <-chan struct{}) {
+	if s.balance == nil || s.balance.path == "" {
+		return
+	}
+	if interval <= 0 {
+		interval = defaultBalanceReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if s.balance.fileChanged() {
+				s.ReloadBalance(s.balance.applyToRunning)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// 진행 중인 게임에 다음 틱부터 적용할 밸런스 예약
+func (g *Game) setPendingBalance(balance *Balance) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.balance.Version == balance.Version && *g.balance == *balance {
+		return
+	}
+	g.pendingBalance = balance
+}
+
+// 예약된 밸런스 적용
+// 알림은 Lock을 푼 뒤 broadcastBalanceUpdate에서 전송
+// g.mutex Lock 상태에서 틱 시작 시 호출
+func (g *Game) applyPendingBalance() {
+	if g.pendingBalance == nil {
+		return
+	}
+	previous := g.balance.Version
+	g.balance = g.pendingBalance
+	g.pendingBalance = nil
+	g.recorder.addBalanceVersion(g.balance.Version)
+	g.logger.Info("Balance applied to running game", "version", g.balance.Version, "previous_version", previous, "tick", g.tick)
+
+	g.balanceNotice = &Message{Type: MessageTypeBalanceUpdated, Payload: g.balance}
+}
+
+// 적용된 밸런스 알림 전송
+// Room Lock을 잡으므로 g.mutex를 잡지 않은 상태에서 호출 (Lock 순서 역전 방지)
+func (g *Game) broadcastBalanceUpdate() {
+	g.mutex.Lock()
+	msg := g.balanceNotice
+	g.balanceNotice = nil
+	g.mutex.Unlock()
+
+	if msg != nil {
+		g.broadcastAndRecord(*msg)
+	}
+}
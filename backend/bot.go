@@ -0,0 +1,407 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+type BotDifficulty string
+
+const (
+	BotDifficultyEasy   BotDifficulty = "easy"
+	BotDifficultyNormal BotDifficulty = "normal"
+	BotDifficultyHard   BotDifficulty = "hard"
+)
+
+const (
+	botIDPrefix      = "bot_"
+	botSendBuffer    = 64
+	botAttackReach   = 1.5             // 봇이 공격을 시도하는 거리 (망치 범위 배수)
+	botFleeDistance  = 4.0             // 도망 중 적과 유지하려는 거리 (망치 범위 배수)
+	botWanderRadius  = mapBoundary / 2 // 대상이 없을 때 배회 반경
+	botArrivedRadius = 1.0             // 배회 목표 도착 판정 거리
+	botAbilityChance = 0.3             // 사거리 안에서 망치 대신 능력을 쓸 확률 (공격 확률에 곱함)
+	botChargeLevel   = 0.75            // 막는 적에게 충전 공격할 때 모으는 정도
+)
+
+// 봇이 능력을 쓰려고 하는 적과의 거리
+var botAbilityRanges = map[string]float64{
+	abilityGroundSlam: groundSlamRadius,
+	abilityTearCloud:  tearCloudRadius,
+	abilityRoll:       6.0,
+	abilitySpicyBurst: spicyBurstRange,
+}
+
+// 난이도별 봇 성향
+type botProfile struct {
+	decisionInterval time.Duration // 판단 주기
+	aimError         float64       // 공격 방향 오차 (라디안)
+	attackChance     float64       // 사거리 안에서 공격할 확률
+	fleeHealth       int           // 이 체력 이하일 때 도망
+}
+
+var botProfiles = map[BotDifficulty]botProfile{
+	BotDifficultyEasy: {
+		decisionInterval: 600 * time.Millisecond,
+		aimError:         0.6,
+		attackChance:     0.4,
+		fleeHealth:       0,
+	},
+	BotDifficultyNormal: {
+		decisionInterval: 300 * time.Millisecond,
+		aimError:         0.3,
+		attackChance:     0.7,
+		fleeHealth:       1,
+	},
+	BotDifficultyHard: {
+		decisionInterval: 100 * time.Millisecond,
+		aimError:         0.1,
+		attackChance:     1.0,
+		fleeHealth:       1,
+	},
+}
+
+// 봇 캐릭터, 색상 후보
+var (
+	botColors = []string{"#E74C3C", "#3498DB", "#2ECC71", "#F1C40F", "#9B59B6", "#E67E22"}
+)
+
+// 게임 내 봇 판단 상태
+type botBrain struct {
+	profile      botProfile
+	lastDecision time.Time
+	wanderX      float64
+	wanderZ      float64
+}
+
+// 유효한 난이도인지 확인
+func isValidBotDifficulty(difficulty BotDifficulty) bool {
+	_, ok := botProfiles[difficulty]
+	return ok
+}
+
+// 봇 클라이언트 생성
+// WebSocket 연결 없이 send 채널만 비워주는 고루틴 실행
+func NewBotClient(server *Server, difficulty BotDifficulty, number int) *Client {
+	botID := botIDPrefix + GenerateUniqueID()
+	bot := &Client{
+		id:            botID,
+		server:        server,
+		conn:          nil,
+		send:          make(chan []byte, botSendBuffer),
+		logger:        server.logger.With("client_id", botID),
+		nickname:      fmt.Sprintf("Bot %d (%s)", number, difficulty),
+		color:         botColors[rand.Intn(len(botColors))],
+		character:     randomCharacter(),
+		isReady:       true,
+		isBot:         true,
+		botDifficulty: difficulty,
+	}
+	go bot.discardPump()
+	return bot
+}
+
+// 봇에게 전달되는 메세지는 모두 버림
+func (c *Client) discardPump() {
+	for range c.send {
+	}
+}
+
+// 방에 있는 사람 플레이어 수
+// r.mutex Lock 상태에서 호출
+func (r *Room) humanCount() int {
+	count := 0
+	for c := range r.clients {
+		if !c.isBot {
+			count++
+		}
+	}
+	return count
+}
+
+// 봇 제거
+// r.mutex Lock 상태에서 호출
+func (r *Room) removeBotLocked(bot *Client) {
+	delete(r.clients, bot)
+	bot.room = nil
+	close(bot.send)
+}
+
+// 방의 모든 봇 제거
+// r.mutex Lock 상태에서 호출
+func (r *Room) removeAllBotsLocked() {
+	for c := range r.clients {
+		if c.isBot {
+			r.removeBotLocked(c)
+		}
+	}
+}
+
+// 봇 추가 요청 처리
+func (r *Room) handleAddBot(msg *Message) {
+	client := msg.Sender
+
+	var payload AddBotPayload
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		r.logger.Warn("Invalid add bot payload", "client_id", client.id, "error", err)
+		r.sendError(client, "잘못된 봇 추가 요청입니다.")
+		return
+	}
+	if payload.Difficulty == "" {
+		payload.Difficulty = BotDifficultyNormal
+	}
+	if !isValidBotDifficulty(payload.Difficulty) {
+		r.sendError(client, "존재하지 않는 봇 난이도입니다.")
+		return
+	}
+
+	r.mutex.Lock()
+	if client != r.owner {
+		r.logger.Warn("Add bot denied: not owner", "client_id", client.id)
+		r.mutex.Unlock()
+		r.sendError(client, "방장만 봇을 추가할 수 있습니다.")
+		return
+	}
+	if r.state != RoomStateWaiting {
+		r.mutex.Unlock()
+		r.sendError(client, "대기 중에만 봇을 추가할 수 있습니다.")
+		return
+	}
+	if len(r.clients) >= r.maxPlayers {
+		r.mutex.Unlock()
+		r.sendError(client, "방이 가득 찼습니다.")
+		return
+	}
+
+	r.botCounter++
+	bot := NewBotClient(r.server, payload.Difficulty, r.botCounter)
+	r.clients[bot] = true
+	bot.room = r
+	if r.settings.TeamMode {
+		bot.team = r.smallestTeam()
+	}
+	r.logger.Info("Bot added", "bot_id", bot.id, "difficulty", bot.botDifficulty, "client_id", client.id, "players", len(r.clients), "max_players", r.maxPlayers)
+	r.mutex.Unlock()
+
+	msgJoined := Message{Type: MessageTypePlayerJoined, Payload: PlayerJoinedPayload{PlayerInfo: r.getPlayerInfo(bot)}}
+	r.broadcastMessage(msgJoined, nil)
+
+	r.server.broadcastRoomUpdate()
+}
+
+// 봇 제거 요청 처리
+func (r *Room) handleRemoveBot(msg *Message) {
+	client := msg.Sender
+
+	var payload RemoveBotPayload
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		r.logger.Warn("Invalid remove bot payload", "client_id", client.id, "error", err)
+		r.sendError(client, "잘못된 봇 제거 요청입니다.")
+		return
+	}
+
+	r.mutex.Lock()
+	if client != r.owner {
+		r.logger.Warn("Remove bot denied: not owner", "client_id", client.id)
+		r.mutex.Unlock()
+		r.sendError(client, "방장만 봇을 제거할 수 있습니다.")
+		return
+	}
+	if r.state != RoomStateWaiting {
+		r.mutex.Unlock()
+		r.sendError(client, "대기 중에만 봇을 제거할 수 있습니다.")
+		return
+	}
+
+	var bot *Client
+	for c := range r.clients {
+		if c.isBot && c.id == payload.BotID {
+			bot = c
+			break
+		}
+	}
+	if bot == nil {
+		r.mutex.Unlock()
+		r.sendError(client, "존재하지 않는 봇입니다.")
+		return
+	}
+	r.removeBotLocked(bot)
+	r.logger.Info("Bot removed", "bot_id", bot.id, "client_id", client.id, "players", len(r.clients))
+	r.mutex.Unlock()
+
+	msgLeft := Message{Type: MessageTypePlayerLeft, Payload: PlayerLeftPayload{PlayerID: bot.id}}
+	r.broadcastMessage(msgLeft, nil)
+
+	r.server.broadcastRoomUpdate()
+}
+
+// 봇 판단 상태 생성
+func newBotBrain(difficulty BotDifficulty) *botBrain {
+	profile, ok := botProfiles[difficulty]
+	if !ok {
+		profile = botProfiles[BotDifficultyNormal]
+	}
+	return &botBrain{profile: profile}
+}
+
+// 모든 봇 행동 갱신
+// 틱마다 이동 입력, 방향, 공격을 사람 플레이어와 같은 PlayerState 필드로 결정
+// g.mutex Lock 상태에서 호출
+func (g *Game) updateBots() {
+	now := time.Now()
+	for client, brain := range g.bots {
+		ps, ok := g.players[client]
+		if !ok || !ps.IsAlive {
+			continue
+		}
+		if now.Sub(brain.lastDecision) < brain.profile.decisionInterval {
+			continue
+		}
+		brain.lastDecision = now
+		g.decideBotAction(ps, brain)
+	}
+}
+
+// 봇 한 명의 행동 결정
+// g.mutex Lock 상태에서 호출
+func (g *Game) decideBotAction(ps *PlayerState, brain *botBrain) {
+	// 충전 중이면 충분히 모은 뒤 대상에게 휘두름
+	if ps.IsCharging {
+		g.decideBotCharge(ps, brain)
+		return
+	}
+
+	// 다른 행동 중에는 판단 Skip
+	if ps.isBusy() {
+		return
+	}
+
+	// 안전 구역 밖이거나 낙하 예정 지점 안이면 먼저 피함
+	if dx, dz, ok := g.dangerEscape(ps); ok {
+		g.setBotMove(ps, dx, dz)
+		return
+	}
+
+	target, distance := g.nearestEnemy(ps)
+
+	// 대상 없으면 배회
+	if target == nil {
+		dx := brain.wanderX - ps.X
+		dz := brain.wanderZ - ps.Z
+		if math.Sqrt(dx*dx+dz*dz) < botArrivedRadius {
+			brain.wanderX = (rand.Float64()*2 - 1) * botWanderRadius
+			brain.wanderZ = (rand.Float64()*2 - 1) * botWanderRadius
+		}
+		g.setBotMove(ps, dx, dz)
+		return
+	}
+
+	dx := target.X - ps.X
+	dz := target.Z - ps.Z
+
+	// 체력 낮으면 도망
+	if ps.Health <= brain.profile.fleeHealth && distance < botFleeDistance*g.hammerRange(ps) {
+		g.setBotMove(ps, -dx, -dz)
+		// 가까이 붙으면 대시로 회피
+		if g.isRunning && distance < g.hammerRange(ps) && g.canDash(ps) && rand.Float64() < brain.profile.attackChance {
+			g.startDash(ps)
+		}
+		return
+	}
+
+	// 능력 사거리 안이면 일정 확률로 능력 사용
+	if g.isRunning && distance <= botAbilityRanges[ps.Ability] && g.canUseAbility(ps) &&
+		rand.Float64() < brain.profile.attackChance*botAbilityChance {
+		g.setBotMove(ps, 0, 0)
+		ps.Yaw = math.Atan2(dx, dz)
+		g.useAbility(ps)
+		return
+	}
+
+	// 사거리 밖이면 추격
+	if distance > botAttackReach*g.hammerRange(ps) {
+		g.setBotMove(ps, dx, dz)
+		return
+	}
+
+	// 사거리 안이면 정지 후 공격
+	g.setBotMove(ps, 0, 0)
+	ps.Yaw = math.Atan2(dx, dz)
+	if !g.isRunning || time.Since(ps.LastAttackTime) < g.hammerCooldown(ps) || rand.Float64() > brain.profile.attackChance {
+		return
+	}
+
+	// 막고 있는 적에게는 충전 공격
+	if target.IsBlocking && g.canCharge(ps) {
+		g.startCharge(ps)
+		return
+	}
+
+	angle := math.Atan2(dz, dx) + (rand.Float64()*2-1)*brain.profile.aimError
+	g.performHammerAttack(ps, math.Cos(angle), math.Sin(angle), 0)
+}
+
+// 봇 충전 공격 판단
+// 대상을 쫓아가며 모으다가 충분히 모였고 사거리 안이면 휘두름
+// g.mutex Lock 상태에서 호출
+func (g *Game) decideBotCharge(ps *PlayerState, brain *botBrain) {
+	target, distance := g.nearestEnemy(ps)
+	if target == nil {
+		g.cancelCharge(ps)
+		return
+	}
+
+	dx := target.X - ps.X
+	dz := target.Z - ps.Z
+	if ps.chargeLevel() < botChargeLevel || distance > botAttackReach*g.hammerRange(ps) {
+		g.setBotMove(ps, dx, dz)
+		return
+	}
+
+	g.setBotMove(ps, 0, 0)
+	ps.Yaw = math.Atan2(dx, dz)
+	angle := math.Atan2(dz, dx) + (rand.Float64()*2-1)*brain.profile.aimError
+	g.releaseCharge(ps, math.Cos(angle), math.Sin(angle))
+}
+
+// 가장 가까운 적
+// 팀 모드에서는 같은 팀 제외, 무적 상태인 적은 제외
+// g.mutex Lock 상태에서 호출
+func (g *Game) nearestEnemy(ps *PlayerState) (*PlayerState, float64) {
+	var nearest *PlayerState
+	nearestDistance := math.MaxFloat64
+	for _, other := range g.players {
+		if other == ps || !other.IsConnected || !other.IsAlive || other.IsInvincible || g.isTeammate(ps, other) {
+			continue
+		}
+		dx := other.X - ps.X
+		dz := other.Z - ps.Z
+		distance := math.Sqrt(dx*dx + dz*dz)
+		if distance < nearestDistance {
+			nearest = other
+			nearestDistance = distance
+		}
+	}
+	return nearest, nearestDistance
+}
+
+// 봇 이동 방향 설정
+// updateGameState의 이동 처리와 같은 축 변환 사용
+// (deltaZ = -MoveForward, deltaX = -MoveStrafe)
+func (g *Game) setBotMove(ps *PlayerState, dx, dz float64) {
+	if !g.isRunning {
+		ps.MoveForward = 0
+		ps.MoveStrafe = 0
+		return
+	}
+	ps.MoveForward = -dz
+	ps.MoveStrafe = -dx
+	if dx != 0 || dz != 0 {
+		ps.Yaw = math.Atan2(dx, dz)
+	}
+}
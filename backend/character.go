@@ -0,0 +1,231 @@
+package backend
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	abilityAnimationPrefix = "ability_"
+
+	// 고유 능력 ID
+	abilityGroundSlam = "ground_slam" // 감자
+	abilityTearCloud  = "tear_cloud"  // 양파
+	abilityRoll       = "roll"        // 토마토
+	abilitySpicyBurst = "spicy_burst" // 파프리카
+
+	groundSlamRadius  = 3.5             // 내려찍기 범위 (자기 주변)
+	tearCloudRadius   = 4.5             // 눈물 구름 범위
+	tearCloudSlow     = 0.5             // 눈물 구름에 걸린 적 이동 속도 배율
+	tearCloudDuration = 3 * time.Second // 눈물 구름 유지 시간
+	rollSpeed         = 1.4             // 구르기 이동 속도 배율
+	rollHitRadius     = 1.5             // 구르는 중 부딪히는 거리
+	spicyBurstRange   = 5.0             // 매운 불꽃 사거리
+	spicyBurstAngle   = math.Pi / 4     // 매운 불꽃 부채꼴 반각
+)
+
+// 캐릭터 능력치
+// 밸런스 수치에 곱하는 배율이라 밸런스를 리로드해도 캐릭터 간 차이는 유지
+type CharacterStats struct {
+	Speed       float64 `json:"speed"`        // 이동 속도 배율
+	HealthBonus int     `json:"health_bonus"` // 최대 체력 가감
+	Reach       float64 `json:"reach"`        // 망치 범위 배율
+	Cooldown    float64 `json:"cooldown"`     // 망치 쿨타임 배율
+}
+
+// 캐릭터 고유 능력
+type AbilityInfo struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Cooldown    Duration `json:"cooldown"`
+	CastTime    Duration `json:"cast_time"` // 애니메이션, 행동 제한 시간
+}
+
+// 고유 능력 목록
+var abilities = map[string]AbilityInfo{
+	abilityGroundSlam: {
+		ID:          abilityGroundSlam,
+		Name:        "내려찍기",
+		Description: "땅을 내려찍어 주변의 적 모두에게 피해를 줍니다.",
+		Cooldown:    Duration(8 * time.Second),
+		CastTime:    Duration(600 * time.Millisecond),
+	},
+	abilityTearCloud: {
+		ID:          abilityTearCloud,
+		Name:        "눈물 구름",
+		Description: "그 자리에 매운 구름을 남겨 안에 들어온 적의 이동 속도를 절반으로 줄입니다.",
+		Cooldown:    Duration(10 * time.Second),
+		CastTime:    Duration(500 * time.Millisecond),
+	},
+	abilityRoll: {
+		ID:          abilityRoll,
+		Name:        "구르기",
+		Description: "바라보는 방향으로 굴러가며 부딪힌 적에게 피해를 줍니다.",
+		Cooldown:    Duration(7 * time.Second),
+		CastTime:    Duration(450 * time.Millisecond),
+	},
+	abilitySpicyBurst: {
+		ID:          abilitySpicyBurst,
+		Name:        "매운 불꽃",
+		Description: "앞쪽 부채꼴 범위에 매운 불꽃을 뿜어 피해를 줍니다.",
+		Cooldown:    Duration(8 * time.Second),
+		CastTime:    Duration(500 * time.Millisecond),
+	},
+}
+
+// 능력 애니메이션 여부
+func isAbilityAnimation(animation string) bool {
+	return strings.HasPrefix(animation, abilityAnimationPrefix)
+}
+
+// 능력 애니메이션 지속 시간
+func abilityAnimationDuration(animation string) time.Duration {
+	ability, ok := abilities[strings.TrimPrefix(animation, abilityAnimationPrefix)]
+	if !ok {
+		return 0
+	}
+	return time.Duration(ability.CastTime)
+}
+
+// 캐릭터 이동 속도 (틱당)
+// g.mutex Lock 상태에서 호출
+func (g *Game) playerSpeed(ps *PlayerState) float64 {
+	speed := g.balance.PlayerSpeed * ps.Stats.Speed * ps.stanceSpeed()
+	if time.Now().Before(ps.SlowedUntil) {
+		speed *= tearCloudSlow
+	}
+	return speed
+}
+
+// 캐릭터 망치 범위
+func (g *Game) hammerRange(ps *PlayerState) float64 {
+	return g.balance.HammerRange * ps.Stats.Reach
+}
+
+// 캐릭터 망치 쿨타임
+func (g *Game) hammerCooldown(ps *PlayerState) time.Duration {
+	return time.Duration(float64(g.balance.HammerCooldown) * ps.Stats.Cooldown)
+}
+
+// 능력 사용 중인지 (행동 제한)
+func (ps *PlayerState) isUsingAbility() bool {
+	ability, ok := abilities[ps.Ability]
+	return ok && time.Since(ps.LastAbilityTime) < time.Duration(ability.CastTime)
+}
+
+// 남은 능력 쿨타임
+func (ps *PlayerState) abilityCooldownLeft() time.Duration {
+	ability, ok := abilities[ps.Ability]
+	if !ok {
+		return 0
+	}
+	return max(0, time.Duration(ability.Cooldown)-time.Since(ps.LastAbilityTime))
+}
+
+// 능력 액션 처리
+// g.mutex Lock 상태에서 호출
+func (g *Game) handleAbilityAction(client *Client, ps *PlayerState) {
+	// 카운트 다운 중에는 능력 사용 불가
+	if !g.isRunning {
+		return
+	}
+	if !g.canUseAbility(ps) {
+		g.logger.Debug("Ability not ready", "client_id", client.id, "ability", ps.Ability)
+		return
+	}
+	g.useAbility(ps)
+}
+
+// 능력을 지금 쓸 수 있는지
+// 쿨타임이 남았거나 다른 행동 중이면 불가
+func (g *Game) canUseAbility(ps *PlayerState) bool {
+	if _, ok := abilities[ps.Ability]; !ok {
+		return false
+	}
+	return ps.abilityCooldownLeft() == 0 && !ps.isBusy()
+}
+
+// 능력 사용
+// 즉시 판정하는 능력은 여기서 처리, 구르기는 시전 시간 동안 updateAbilityMovement에서 처리
+// 플레이어 ability 액션과 봇이 공통으로 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) useAbility(ps *PlayerState) {
+	now := time.Now()
+	ps.LastAbilityTime = now
+	ps.CurrentAnimation = abilityAnimationPrefix + ps.Ability
+	ps.AnimationStart = now
+
+	// 바라보는 방향 (sin yaw, cos yaw)
+	ps.AbilityDirX, ps.AbilityDirZ = math.Sin(ps.Yaw), math.Cos(ps.Yaw)
+
+	switch ps.Ability {
+	case abilityGroundSlam:
+		// 위에서 내려찍으므로 막기를 깸
+		damage := damageInfo{amount: g.balance.HammerDamage, sourceX: ps.X, sourceZ: ps.Z, breaksBlock: true, source: ps.Ability}
+		for _, other := range g.players {
+			if other != ps && math.Hypot(other.X-ps.X, other.Z-ps.Z) <= groundSlamRadius {
+				g.damagePlayer(ps, other, damage, now)
+			}
+		}
+
+	case abilityTearCloud:
+		// 제자리에 남는 지속 범위 효과
+		g.addAttackEntity(&AttackEntity{
+			Kind:         AttackEntityZone,
+			Type:         abilityTearCloud,
+			X:            ps.X,
+			Z:            ps.Z,
+			Radius:       tearCloudRadius,
+			owner:        ps,
+			lifetime:     tearCloudDuration,
+			tickInterval: gameTickRate,
+			slowDuration: tearCloudSlowLinger,
+		})
+
+	case abilitySpicyBurst:
+		damage := damageInfo{amount: g.balance.HammerDamage, sourceX: ps.X, sourceZ: ps.Z, source: ps.Ability}
+		for _, other := range g.players {
+			if other == ps {
+				continue
+			}
+			dx, dz := other.X-ps.X, other.Z-ps.Z
+			distance := math.Hypot(dx, dz)
+			if distance > spicyBurstRange {
+				continue
+			}
+			// 겹쳐 있으면 방향과 상관없이 맞음
+			if distance > 1e-6 {
+				dot := math.Max(-1, math.Min(1, (dx*ps.AbilityDirX+dz*ps.AbilityDirZ)/distance))
+				if math.Acos(dot) > spicyBurstAngle {
+					continue
+				}
+			}
+			g.damagePlayer(ps, other, damage, now)
+		}
+	}
+
+	g.logger.Debug("Ability used", "client_id", ps.ID, "ability", ps.Ability)
+}
+
+// 능력 시전 중 이동 처리
+// g.mutex Lock 상태에서 호출
+func (g *Game) updateAbilityMovement(ps *PlayerState) {
+	if ps.Ability != abilityRoll {
+		return
+	}
+
+	speed := g.balance.PlayerSpeed * ps.Stats.Speed * rollSpeed
+	ps.X = math.Max(-mapBoundary+1, math.Min(mapBoundary-1, ps.X+ps.AbilityDirX*speed))
+	ps.Z = math.Max(-mapBoundary+1, math.Min(mapBoundary-1, ps.Z+ps.AbilityDirZ*speed))
+
+	// 부딪힌 적은 피격 무적이 걸리므로 한 번의 구르기에서 한 번만 맞음
+	now := time.Now()
+	damage := damageInfo{amount: g.balance.HammerDamage, sourceX: ps.X, sourceZ: ps.Z, source: ps.Ability}
+	for _, other := range g.players {
+		if other != ps && math.Hypot(other.X-ps.X, other.Z-ps.Z) <= rollHitRadius {
+			g.damagePlayer(ps, other, damage, now)
+		}
+	}
+}
@@ -2,7 +2,8 @@ package backend
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -15,22 +16,53 @@ const (
 	pongWait = 60 * time.Second
 	// ping interval
 	pingPeriod = (pongWait * 9) / 10
-	// 최대 메세지 크기
+	// 최대 메세지 크기 기본값
 	maxMessageSize = 1024 * 4 // 4KB
+	// close frame 전송 후 상대방 응답 대기 시간
+	closeGracePeriod = time.Second
 )
 
+// 서버 측 연결 종료 요청
+type closeRequest struct {
+	code   int
+	reason string
+}
+
 type Client struct {
 	id     string
 	server *Server
 	conn   *websocket.Conn
 	send   chan []byte
+	logger *slog.Logger // client_id 포함
+
+	// 연결 종료 요청, readPump 종료 알림
+	disconnect chan closeRequest
+	readDone   chan struct{}
+
+	// 요청 제한, IP별 연결 수 집계용 주소
+	rateLimiter *clientRateLimiter
+	remoteIP    string
+
+	room *Room
+
+	// 프로필
+	// 방에 있으면 r.mutex와 profileMutex를 함께 잡고 변경, 로비에서는 profileMutex만 잡고 변경
+	// profileMutex를 잡은 상태에서는 다른 Lock을 잡지 않음
+	profileMutex sync.RWMutex
+	nickname     string
+	color        string
+	character    string
 
-	room      *Room
-	nickname  string
-	color     string
-	character string
-	isReady   bool
-	isOwner   bool
+	team    string
+	isReady bool
+	isOwner bool
+
+	// 시청 중인 리플레이
+	replay *replayPlayer
+
+	// 봇 정보 (conn 없음)
+	isBot         bool
+	botDifficulty BotDifficulty
 }
 
 func NewClient(server *Server, conn *websocket.Conn, clientID string) *Client {
@@ -38,13 +70,51 @@ func NewClient(server *Server, conn *websocket.Conn, clientID string) *Client {
 		clientID = GenerateUniqueID()
 	}
 	return &Client{
-		id:        clientID,
-		server:    server,
-		conn:      conn,
-		send:      make(chan []byte, 256),
-		nickname:  defaultClientID,
-		color:     "#FFFFFF",
-		character: "onion",
+		id:          clientID,
+		server:      server,
+		conn:        conn,
+		send:        make(chan []byte, server.config.Limits.ClientSendBuffer),
+		logger:      server.logger.With("client_id", clientID),
+		disconnect:  make(chan closeRequest, 1),
+		readDone:    make(chan struct{}),
+		rateLimiter: newClientRateLimiter(),
+		nickname:    defaultClientID,
+		color:       "#FFFFFF",
+		character:   "onion",
+	}
+}
+
+// 클라이언트가 있는 방을 r.mutex Lock 상태로 반환 (방에 없으면 nil)
+// client.room은 방 고루틴이 r.mutex Lock 상태에서 바꾸므로 Lock 후 같은 방인지 다시 확인
+// Lock 순서: s.mutex -> r.mutex, r.mutex를 잡은 상태에서 s.mutex를 잡지 않음
+func (c *Client) lockRoom() *Room {
+	for {
+		room := c.room
+		if room == nil {
+			return nil
+		}
+		room.mutex.Lock()
+		if c.room == room {
+			return room
+		}
+		room.mutex.Unlock()
+	}
+}
+
+// 프로필 스냅샷 (관리자 API 등 방 밖의 고루틴에서 읽을 때)
+func (c *Client) profile() (nickname, color, character string) {
+	c.profileMutex.RLock()
+	defer c.profileMutex.RUnlock()
+	return c.nickname, c.color, c.character
+}
+
+// 서버 측에서 연결 종료
+// 대기 중인 메세지를 모두 보낸 뒤 close frame 전송
+// 봇이거나 이미 종료 요청된 경우 무시
+func (c *Client) Disconnect(code int, reason string) {
+	select {
+	case c.disconnect <- closeRequest{code: code, reason: reason}:
+	default:
 	}
 }
 
@@ -53,16 +123,20 @@ func NewClient(server *Server, conn *websocket.Conn, clientID string) *Client {
 func (c *Client) readPump() {
 	defer func() {
 		// Room에 있으면 unregister
-		if c.room != nil {
-			c.room.unregister <- c
+		if room := c.room; room != nil {
+			sendToRoom(room, room.unregister, c)
 		}
 		// Server에서도 unregister
 		c.server.unregister <- c
+		if c.remoteIP != "" {
+			c.server.releaseIPSlot(c.remoteIP)
+		}
 		c.conn.Close()
-		log.Printf("Client %s (Nick: %s) disconnected and cleaned up from readPump.", c.id, c.nickname)
+		close(c.readDone)
+		c.logger.Info("Client disconnected and cleaned up", "nickname", c.nickname)
 	}()
 
-	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadLimit(c.server.config.Limits.MaxMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error {
 		c.conn.SetReadDeadline(time.Now().Add(pongWait))
@@ -74,9 +148,9 @@ func (c *Client) readPump() {
 		// Unhandled error
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("Client %s (Nick: %s) unexpected websocket error: %v", c.id, c.nickname, err)
+				c.logger.Warn("Unexpected websocket error", "error", err)
 			} else {
-				log.Printf("Client %s (Nick: %s) websocket error (likely closed): %v", c.id, c.nickname, err)
+				c.logger.Debug("Websocket closed", "error", err)
 			}
 			// 루프 종료
 			// defer 실행
@@ -84,11 +158,15 @@ func (c *Client) readPump() {
 		}
 
 		// 로그용
-		// log.Printf("Received raw message from %s: %s", c.id, string(rawMessage))
+		// c.logger.Debug("Received raw message", "raw", string(rawMessage))
 
 		var msg Message
 		if err := json.Unmarshal(rawMessage, &msg); err != nil {
-			log.Printf("Client %s (Nick: %s) sent invalid JSON: %v. Raw: %s", c.id, c.nickname, err, string(rawMessage))
+			metrics.messagesIn.Inc("invalid_json")
+			if !c.allowMessage(&Message{Type: "invalid_json"}) {
+				continue
+			}
+			c.logger.Warn("Invalid JSON from client", "error", err, "raw", string(rawMessage))
 
 			errorMsg := Message{
 				Type:    MessageTypeError,
@@ -104,6 +182,11 @@ func (c *Client) readPump() {
 		// Sender 정보 추가
 		msg.Sender = c
 
+		// 요청 제한 초과 시 버림
+		if !c.allowMessage(&msg) {
+			continue
+		}
+
 		// 메시지 라우팅
 		// 1. 초기 설정 (닉네임, 색상)
 		// 2. 방 관련 요청 (생성, 참가, 나가기, 레디 등) -> Room 또는 Server의 방 관리 로직으로
@@ -111,7 +194,7 @@ func (c *Client) readPump() {
 
 		if c.room != nil && c.room.game != nil && (msg.Type == MessageTypeGameLoadingComplete || msg.Type == MessageTypePlayerAction) {
 			// 게임 진행중이면 room의 clientMessage 채널이 처리
-			c.room.clientMessage <- &msg
+			sendToRoom(c.room, c.room.clientMessage, &msg)
 		} else if msg.Type == MessageTypeCreateRoom ||
 			msg.Type == MessageTypeJoinRoom ||
 			msg.Type == MessageTypeListRooms {
@@ -119,22 +202,35 @@ func (c *Client) readPump() {
 			c.server.routeClientMessage <- &msg
 		} else if msg.Type == MessageTypeLeaveRoom ||
 			msg.Type == MessageTypeReadyToggle ||
-			msg.Type == MessageTypeStartGame {
-			// 방 나가기, 준비, 게임 시작은 현재 Client가 속한 room의 clientMessage 채널이 처리
+			msg.Type == MessageTypeStartGame ||
+			msg.Type == MessageTypeUpdateRoomSettings ||
+			msg.Type == MessageTypeSetTeam ||
+			msg.Type == MessageTypeAutoBalanceTeams ||
+			msg.Type == MessageTypeAddBot ||
+			msg.Type == MessageTypeRemoveBot {
+			// 방 나가기, 준비, 게임 시작, 방 설정, 팀 선택, 봇 관리는 현재 Client가 속한 room의 clientMessage 채널이 처리
 			if c.room != nil {
-				log.Println("Room client message received")
-				c.room.clientMessage <- &msg
+				c.logger.Debug("Room client message received", "type", msg.Type)
+				sendToRoom(c.room, c.room.clientMessage, &msg)
 			} else {
-				log.Printf("Client %s (Nick: %s) sent room-specific message %s without being in a room.", c.id, c.nickname, msg.Type)
+				c.logger.Warn("Room-specific message without a room", "type", msg.Type)
 				// 에러 응답
 			}
+		} else if msg.Type == MessageTypeWatchReplay || msg.Type == MessageTypeReplayControl {
+			// 리플레이 시청은 Server가 처리
+			c.server.routeClientMessage <- &msg
 		} else if msg.Type == MessageTypeSetNicknameColor {
 			// 닉네임/색상 설정은 Server가 처리하여 Client 객체에 반영
 			c.server.routeClientMessage <- &msg
 		} else {
-			log.Printf("Client %s (Nick: %s) sent unhandled message type: %s", c.id, c.nickname, msg.Type)
+			// 임의 타입을 계속 보내면 로그가 넘치므로 Debug로 남김 (요청 제한은 other 버킷 하나로 적용)
+			c.logger.Debug("Unhandled message type", "type", msg.Type)
 			// 에러 응답
+			// 임의 타입으로 라벨이 늘어나지 않도록 하나로 집계
+			metrics.messagesIn.Inc("unhandled")
+			continue
 		}
+		metrics.messagesIn.Inc(string(msg.Type))
 	}
 }
 
@@ -145,7 +241,7 @@ func (c *Client) writePump() {
 	defer func() {
 		ticker.Stop()
 		c.conn.Close()
-		log.Printf("Client %s (Nick: %s) writePump stopped.", c.id, c.nickname)
+		c.logger.Debug("writePump stopped")
 	}()
 
 	for {
@@ -155,33 +251,66 @@ func (c *Client) writePump() {
 			if !ok {
 				// send 채널 닫혔을 시 close 처리
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
-				log.Printf("Client %s (Nick: %s) send channel closed.", c.id, c.nickname)
+				c.logger.Debug("Send channel closed")
 				return
 			}
 
 			w, err := c.conn.NextWriter(websocket.TextMessage)
 			if err != nil {
-				log.Printf("Client %s (Nick: %s) error getting next writer: %v", c.id, c.nickname, err)
+				c.logger.Warn("Failed to get next writer", "error", err)
 				return
 			}
 			_, err = w.Write(messageBytes)
 			if err != nil {
-				log.Printf("Client %s (Nick: %s) error writing message: %v", c.id, c.nickname, err)
+				c.logger.Warn("Failed to write message", "error", err)
 				return
 			}
+			metrics.messagesOut.Inc(outgoingMessageType(messageBytes))
 
 			if err := w.Close(); err != nil {
-				log.Printf("Client %s (Nick: %s) error closing writer: %v", c.id, c.nickname, err)
+				c.logger.Warn("Failed to close writer", "error", err)
 				return
 			}
+		case req := <-c.disconnect:
+			c.flushPending()
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(req.code, req.reason))
+			c.logger.Info("Disconnected by server", "code", req.code, "reason", req.reason)
+
+			// 상대방 close frame 응답까지 대기 후 연결 종료
+			select {
+			case <-c.readDone:
+			case <-time.After(closeGracePeriod):
+			}
+			return
 		case <-ticker.C:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 
 			// Ping 실패시
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				log.Printf("Client %s (Nick: %s) error sending ping: %v", c.id, c.nickname, err)
+				c.logger.Debug("Failed to send ping", "error", err)
+				return
+			}
+		}
+	}
+}
+
+// send 채널에 남아있는 메세지 전송
+// 연결 종료 직전 game_ended 등이 유실되지 않도록 사용
+func (c *Client) flushPending() {
+	for {
+		select {
+		case messageBytes, ok := <-c.send:
+			if !ok {
+				return
+			}
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, messageBytes); err != nil {
 				return
 			}
+			metrics.messagesOut.Inc(outgoingMessageType(messageBytes))
+		default:
+			return
 		}
 	}
 }
@@ -190,17 +319,18 @@ func (c *Client) writePump() {
 func (c *Client) sendInfoToClient() {
 	msg := Message{
 		Type:    MessageTypeUserIDAssigned,
-		Payload: UserIDAssignedPayload{UserID: c.id},
+		Payload: UserIDAssignedPayload{UserID: c.id, Characters: characterCatalog},
 	}
 	payloadBytes, err := json.Marshal(msg)
 	if err != nil {
-		log.Printf("Error marshalling user ID assigned message for %s: %v", c.id, err)
+		metrics.marshalErrors.Add(1)
+		c.logger.Error("Failed to marshal user ID assigned message", "error", err)
 		return
 	}
 
 	if c.send != nil {
 		c.send <- payloadBytes
 	} else {
-		log.Printf("Warning: send channel for client %s is nil when trying to send user ID.", c.id)
+		c.logger.Warn("Send channel is nil when sending user ID")
 	}
 }
@@ -0,0 +1,204 @@
+package backend
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// 충전 공격
+	maxChargeTime        = 1200 * time.Millisecond // 이 시간 이상 모으면 최대 충전
+	chargeTimeout        = 5 * time.Second         // 이 시간 동안 놓지 않으면 충전 취소 (키 뗌 유실 대비)
+	heavyMinCharge       = 0.25                    // 이보다 적게 모으면 일반 공격
+	heavyMaxBonusDamage  = 1                       // 최대 충전 시 추가 데미지 (반올림, 절반 이상 모으면 적용)
+	heavyRangeBonus      = 0.5                     // 최대 충전 시 망치 범위 증가 비율
+	heavyMaxRecovery     = 700 * time.Millisecond  // 최대 충전 시 추가 후딜
+	chargeMoveSpeed      = 0.5                     // 충전 중 이동 속도 배율
+	chargeAnimation      = "charge"
+	heavyAttackAnimation = "heavy_attack"
+
+	// 막기
+	blockMoveSpeed     = 0.4                     // 막기 중 이동 속도 배율
+	blockAngle         = math.Pi / 3             // 정면 판정 반각
+	guardBreakDuration = 1500 * time.Millisecond // 막기가 깨진 뒤 다시 막을 수 없는 시간
+	blockAnimation     = "block"
+)
+
+// 피해 정보
+type damageInfo struct {
+	amount      int
+	sourceX     float64 // 피해가 들어온 위치 (막기 방향 판정용)
+	sourceZ     float64
+	breaksBlock bool   // 막기를 깨는 공격
+	source      string // 피해 원인 (이벤트용)
+	// 환경 피해 (안전 구역 밖, 낙하물)
+	// 무적 상태여도 들어가고 피격 무적을 주지 않음, 주기는 환경 쪽에서 관리
+	environmental bool
+}
+
+// 공격 중인지 (망치 애니메이션, 충전 공격 후딜 포함)
+func (ps *PlayerState) isAttacking() bool {
+	return time.Since(ps.LastAttackTime) < hammerDuration || time.Now().Before(ps.RecoverUntil)
+}
+
+// 피격 경직 중인지
+func (ps *PlayerState) isStunned() bool {
+	return time.Since(ps.LastHitTime) < hitDuration
+}
+
+// 다른 행동을 시작할 수 없는 상태인지
+// 공격, 피격, 능력 사용, 대시, 충전, 막기 중
+func (ps *PlayerState) isBusy() bool {
+	return ps.isAttacking() || ps.isStunned() || ps.isUsingAbility() || ps.isDashing() || ps.IsCharging || ps.IsBlocking
+}
+
+// 충전 정도 (0~1)
+func (ps *PlayerState) chargeLevel() float64 {
+	if !ps.IsCharging {
+		return 0
+	}
+	return math.Min(1, float64(time.Since(ps.ChargeStart))/float64(maxChargeTime))
+}
+
+// 막기, 충전 중 이동 속도 배율
+func (ps *PlayerState) stanceSpeed() float64 {
+	switch {
+	case ps.IsBlocking:
+		return blockMoveSpeed
+	case ps.IsCharging:
+		return chargeMoveSpeed
+	}
+	return 1
+}
+
+// 충전 시작 처리
+// g.mutex Lock 상태에서 호출
+func (g *Game) handleChargeStart(client *Client, ps *PlayerState) {
+	if !g.canCharge(ps) {
+		g.logger.Debug("Charge not ready", "client_id", client.id)
+		return
+	}
+	g.startCharge(ps)
+}
+
+// 충전을 시작할 수 있는지
+func (g *Game) canCharge(ps *PlayerState) bool {
+	return time.Since(ps.LastAttackTime) >= g.hammerCooldown(ps) && !ps.isBusy()
+}
+
+// 충전 시작
+// 플레이어 charge_start 액션과 봇이 공통으로 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) startCharge(ps *PlayerState) {
+	now := time.Now()
+	ps.IsCharging = true
+	ps.ChargeStart = now
+	ps.CurrentAnimation = chargeAnimation
+	ps.AnimationStart = now
+}
+
+// 충전 공격 처리
+// 방향은 click과 같은 검증 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) handleChargeRelease(client *Client, ps *PlayerState, actionData map[string]interface{}) {
+	if !ps.IsCharging {
+		return
+	}
+
+	directionData, _ := actionData["direction"].(map[string]interface{})
+	dirX, okX := directionData["x"].(float64)
+	dirZ, okZ := directionData["z"].(float64)
+	if !okX || !okZ {
+		g.logger.Debug("Charge release without direction", "client_id", client.id)
+		g.cancelCharge(ps)
+		return
+	}
+	dirX, dirZ, ok := g.validateAttackDirection(ps, dirX, dirZ, actionData)
+	if !ok {
+		g.cancelCharge(ps)
+		return
+	}
+	g.releaseCharge(ps, dirX, dirZ)
+}
+
+// 충전 공격 발동
+// 플레이어 charge_release 액션과 봇이 공통으로 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) releaseCharge(ps *PlayerState, dirX, dirZ float64) {
+	level := ps.chargeLevel()
+	ps.IsCharging = false
+	g.performHammerAttack(ps, dirX, dirZ, level)
+}
+
+// 충전 취소
+func (g *Game) cancelCharge(ps *PlayerState) {
+	if !ps.IsCharging {
+		return
+	}
+	ps.IsCharging = false
+	ps.CurrentAnimation = "idle"
+	ps.AnimationStart = time.Now()
+}
+
+// 막기 처리
+// data.active가 true면 막기 시작, false면 해제
+// g.mutex Lock 상태에서 호출
+func (g *Game) handleBlockAction(client *Client, ps *PlayerState, data map[string]interface{}) {
+	active, _ := data["active"].(bool)
+	if !active {
+		if ps.IsBlocking {
+			ps.IsBlocking = false
+			ps.CurrentAnimation = "idle"
+			ps.AnimationStart = time.Now()
+		}
+		return
+	}
+
+	if ps.IsBlocking {
+		return
+	}
+	if ps.isBusy() || time.Now().Before(ps.GuardBrokenUntil) {
+		g.logger.Debug("Block not ready", "client_id", client.id)
+		return
+	}
+
+	ps.IsBlocking = true
+	ps.CurrentAnimation = blockAnimation
+	ps.AnimationStart = time.Now()
+}
+
+// 막기로 피해를 막았는지 확인
+// 정면에서 들어온 피해만 막음, 막기를 깨는 공격이면 막기 해제 후 피해는 그대로 받음
+// g.mutex Lock 상태에서 호출
+func (g *Game) tryBlock(victim *PlayerState, damage damageInfo, now time.Time) bool {
+	if !victim.IsBlocking {
+		return false
+	}
+
+	dx, dz := damage.sourceX-victim.X, damage.sourceZ-victim.Z
+	distance := math.Hypot(dx, dz)
+	if distance < 1e-6 {
+		return false
+	}
+	// 바라보는 방향 (sin yaw, cos yaw)과 비교
+	dot := math.Max(-1, math.Min(1, (dx*math.Sin(victim.Yaw)+dz*math.Cos(victim.Yaw))/distance))
+	if math.Acos(dot) > blockAngle {
+		return false
+	}
+
+	if damage.breaksBlock {
+		victim.IsBlocking = false
+		victim.GuardBrokenUntil = now.Add(guardBreakDuration)
+		g.logger.Debug("Guard broken", "victim_id", victim.ID)
+		return false
+	}
+	return true
+}
+
+// 충전 시간 초과 처리
+// g.mutex Lock 상태에서 호출
+func (g *Game) updateCharge(ps *PlayerState) {
+	if ps.IsCharging && time.Since(ps.ChargeStart) >= chargeTimeout {
+		g.cancelCharge(ps)
+	}
+}
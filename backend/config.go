@@ -0,0 +1,341 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 방 최대 인원 설정 상한
+const maxConfigPlayers = 16
+
+// 설정 파일에서 "30s", "2m" 같은 문자열로 쓰는 시간 값
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\": %s", data)
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// 서버 설정
+// 기본값 < 설정 파일 < 환경 변수 순으로 적용
+type Config struct {
+	ListenAddr     string   `json:"listen_addr"`
+	StaticDir      string   `json:"static_dir"`
+	AllowedOrigins []string `json:"allowed_origins"` // "*"이면 모든 Origin 허용, 같은 호스트는 항상 허용
+
+	TLS      TLSConfig      `json:"tls"`
+	Limits   LimitsConfig   `json:"limits"`
+	Gameplay GameplayConfig `json:"gameplay"`
+	Balance  BalanceConfig  `json:"balance"`
+}
+
+// TLS 인증서 (둘 다 비어있으면 평문 HTTP)
+type TLSConfig struct {
+	CertFile       string   `json:"cert_file"`
+	KeyFile        string   `json:"key_file"`
+	ReloadInterval Duration `json:"reload_interval"` // 인증서 파일 변경 확인 주기
+	RedirectAddr   string   `json:"redirect_addr"`   // HTTP → HTTPS 리다이렉트 리스너 주소 (비어있으면 사용 안 함)
+}
+
+// TLS 사용 여부
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// 연결, 버퍼 제한
+type LimitsConfig struct {
+	MaxMessageSize      int64 `json:"max_message_size"`       // 클라이언트 메세지 최대 크기 (바이트)
+	ClientSendBuffer    int   `json:"client_send_buffer"`     // 클라이언트 송신 채널 크기
+	RoomBroadcastBuffer int   `json:"room_broadcast_buffer"`  // 방 브로드캐스트 채널 크기
+	MaxRooms            int   `json:"max_rooms"`              // 0이면 제한 없음
+	MaxClients          int   `json:"max_clients"`            // 0이면 제한 없음
+	MaxConnectionsPerIP int   `json:"max_connections_per_ip"` // 0이면 제한 없음
+}
+
+// 게임 기본값
+type GameplayConfig struct {
+	MaxPlayers       int      `json:"max_players"`
+	GameDuration     Duration `json:"game_duration"`
+	CountdownSeconds int      `json:"countdown_seconds"`
+	RespawnDelay     Duration `json:"respawn_delay"`
+	MaxHealth        int      `json:"max_health"`
+}
+
+// 밸런스 리로드 설정
+type BalanceConfig struct {
+	File                string   `json:"file"`                   // 밸런스 JSON 파일 (비어있으면 기본 밸런스 고정)
+	ReloadInterval      Duration `json:"reload_interval"`        // 파일 변경 확인 주기
+	ApplyToRunningGames bool     `json:"apply_to_running_games"` // 파일 변경 시 진행 중인 게임에도 다음 틱부터 적용
+}
+
+// 기본 설정
+func DefaultConfig() Config {
+	return Config{
+		ListenAddr:     ":8080",
+		StaticDir:      "./static",
+		AllowedOrigins: []string{"http://localhost:8080", "https://cas3205.myeonghoonlee.cloud"},
+		TLS: TLSConfig{
+			ReloadInterval: Duration(defaultCertReloadInterval),
+		},
+		Limits: LimitsConfig{
+			MaxMessageSize:      maxMessageSize,
+			ClientSendBuffer:    256,
+			RoomBroadcastBuffer: 256,
+			MaxConnectionsPerIP: 16,
+		},
+		Gameplay: GameplayConfig{
+			MaxPlayers:       defaultMaxPlayers,
+			GameDuration:     Duration(defaultGameDuration),
+			CountdownSeconds: countdownSeconds,
+			RespawnDelay:     Duration(respawnDelay),
+			MaxHealth:        maxPlayerHealth,
+		},
+		Balance: BalanceConfig{
+			ReloadInterval: Duration(defaultBalanceReloadInterval),
+		},
+	}
+}
+
+// 설정 불러오기
+// path가 비어있으면 설정 파일 없이 기본값과 환경 변수만 사용
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("read config: %w", err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&cfg); err != nil {
+			return cfg, fmt.Errorf("parse config %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// 환경 변수 이름과 적용 함수
+func (c *Config) envOverrides() map[string]func(string) error {
+	return map[string]func(string) error{
+		"GAME_LISTEN_ADDR": func(v string) error { c.ListenAddr = v; return nil },
+		"GAME_STATIC_DIR":  func(v string) error { c.StaticDir = v; return nil },
+		"GAME_ALLOWED_ORIGINS": func(v string) error {
+			c.AllowedOrigins = nil
+			for _, origin := range strings.Split(v, ",") {
+				if origin = strings.TrimSpace(origin); origin != "" {
+					c.AllowedOrigins = append(c.AllowedOrigins, origin)
+				}
+			}
+			return nil
+		},
+		"GAME_TLS_CERT_FILE":           func(v string) error { c.TLS.CertFile = v; return nil },
+		"GAME_TLS_KEY_FILE":            func(v string) error { c.TLS.KeyFile = v; return nil },
+		"GAME_TLS_RELOAD_INTERVAL":     envDuration(&c.TLS.ReloadInterval),
+		"GAME_TLS_REDIRECT_ADDR":       func(v string) error { c.TLS.RedirectAddr = v; return nil },
+		"GAME_MAX_MESSAGE_SIZE":        envInt64(&c.Limits.MaxMessageSize),
+		"GAME_CLIENT_SEND_BUFFER":      envInt(&c.Limits.ClientSendBuffer),
+		"GAME_ROOM_BROADCAST_BUFFER":   envInt(&c.Limits.RoomBroadcastBuffer),
+		"GAME_MAX_ROOMS":               envInt(&c.Limits.MaxRooms),
+		"GAME_MAX_CLIENTS":             envInt(&c.Limits.MaxClients),
+		"GAME_MAX_CONNECTIONS_PER_IP":  envInt(&c.Limits.MaxConnectionsPerIP),
+		"GAME_MAX_PLAYERS":             envInt(&c.Gameplay.MaxPlayers),
+		"GAME_DURATION":                envDuration(&c.Gameplay.GameDuration),
+		"GAME_COUNTDOWN_SECONDS":       envInt(&c.Gameplay.CountdownSeconds),
+		"GAME_RESPAWN_DELAY":           envDuration(&c.Gameplay.RespawnDelay),
+		"GAME_MAX_HEALTH":              envInt(&c.Gameplay.MaxHealth),
+		"GAME_BALANCE_FILE":            func(v string) error { c.Balance.File = v; return nil },
+		"GAME_BALANCE_RELOAD_INTERVAL": envDuration(&c.Balance.ReloadInterval),
+		"GAME_BALANCE_APPLY_TO_RUNNING": func(v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("not a boolean: %q", v)
+			}
+			c.Balance.ApplyToRunningGames = b
+			return nil
+		},
+	}
+}
+
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	for name, apply := range c.envOverrides() {
+		value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := apply(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func envInt(target *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("not an integer: %q", v)
+		}
+		*target = n
+		return nil
+	}
+}
+
+func envInt64(target *int64) func(string) error {
+	return func(v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("not an integer: %q", v)
+		}
+		*target = n
+		return nil
+	}
+}
+
+func envDuration(target *Duration) func(string) error {
+	return func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*target = Duration(d)
+		return nil
+	}
+}
+
+// 설정 검증
+// 잘못된 항목을 모두 모아서 반환
+func (c Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if _, port, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		fail("listen_addr %q: %v", c.ListenAddr, err)
+	} else if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		fail("listen_addr %q: invalid port", c.ListenAddr)
+	}
+
+	if info, err := os.Stat(c.StaticDir); err != nil || !info.IsDir() {
+		fail("static_dir %q is not a directory", c.StaticDir)
+	}
+
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+			fail("allowed_origins: %q must look like https://example.com", origin)
+		}
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		fail("tls: cert_file and key_file must be set together")
+	}
+	for _, file := range []string{c.TLS.CertFile, c.TLS.KeyFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			fail("tls: %v", err)
+		}
+	}
+	if c.TLS.ReloadInterval < Duration(time.Second) {
+		fail("tls.reload_interval must be at least 1s")
+	}
+	if c.TLS.RedirectAddr != "" {
+		if !c.TLS.Enabled() {
+			fail("tls.redirect_addr requires cert_file and key_file")
+		}
+		if _, _, err := net.SplitHostPort(c.TLS.RedirectAddr); err != nil {
+			fail("tls.redirect_addr %q: %v", c.TLS.RedirectAddr, err)
+		} else if c.TLS.RedirectAddr == c.ListenAddr {
+			fail("tls.redirect_addr must differ from listen_addr")
+		}
+	}
+
+	if c.Limits.MaxMessageSize < 512 {
+		fail("limits.max_message_size must be at least 512 bytes")
+	}
+	if c.Limits.ClientSendBuffer < 1 {
+		fail("limits.client_send_buffer must be at least 1")
+	}
+	if c.Limits.RoomBroadcastBuffer < 1 {
+		fail("limits.room_broadcast_buffer must be at least 1")
+	}
+	if c.Limits.MaxRooms < 0 {
+		fail("limits.max_rooms must not be negative (0 = unlimited)")
+	}
+	if c.Limits.MaxClients < 0 {
+		fail("limits.max_clients must not be negative (0 = unlimited)")
+	}
+	if c.Limits.MaxConnectionsPerIP < 0 {
+		fail("limits.max_connections_per_ip must not be negative (0 = unlimited)")
+	}
+
+	if c.Gameplay.MaxPlayers < 1 || c.Gameplay.MaxPlayers > maxConfigPlayers {
+		fail("gameplay.max_players must be between 1 and %d", maxConfigPlayers)
+	}
+	if c.Gameplay.GameDuration < Duration(10*time.Second) {
+		fail("gameplay.game_duration must be at least 10s")
+	}
+	if c.Gameplay.CountdownSeconds < 0 {
+		fail("gameplay.countdown_seconds must not be negative")
+	}
+	if c.Gameplay.RespawnDelay < 0 {
+		fail("gameplay.respawn_delay must not be negative")
+	}
+	if c.Gameplay.MaxHealth < 1 {
+		fail("gameplay.max_health must be at least 1")
+	}
+
+	if c.Balance.ReloadInterval < Duration(time.Second) {
+		fail("balance.reload_interval must be at least 1s")
+	}
+
+	return errors.Join(errs...)
+}
+
+// WebSocket Origin 허용 여부
+// Origin이 없거나 같은 호스트면 허용
+func (c Config) isOriginAllowed(origin, host string) bool {
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, host) {
+		return true
+	}
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 검증을 통과하는 기본 설정
+func validTestConfig(t *testing.T) Config {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.StaticDir = t.TempDir()
+	return cfg
+}
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	if err := validTestConfig(t).Validate(); err != nil {
+		t.Fatalf("default config rejected: %v", err)
+	}
+}
+
+func TestConfigValidateRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{"listen addr without port", func(c *Config) { c.ListenAddr = "localhost" }, "listen_addr"},
+		{"listen port out of range", func(c *Config) { c.ListenAddr = ":70000" }, "invalid port"},
+		{"missing static dir", func(c *Config) { c.StaticDir = filepath.Join(c.StaticDir, "missing") }, "static_dir"},
+		{"origin with path", func(c *Config) { c.AllowedOrigins = []string{"https://example.com/game"} }, "allowed_origins"},
+		{"origin without scheme", func(c *Config) { c.AllowedOrigins = []string{"example.com"} }, "allowed_origins"},
+		{"tls cert without key", func(c *Config) { c.TLS.CertFile = "cert.pem" }, "cert_file and key_file must be set together"},
+		{"tls key without cert", func(c *Config) { c.TLS.KeyFile = "key.pem" }, "cert_file and key_file must be set together"},
+		{"tls cert file missing", func(c *Config) {
+			c.TLS.CertFile = filepath.Join(c.StaticDir, "cert.pem")
+			c.TLS.KeyFile = filepath.Join(c.StaticDir, "key.pem")
+		}, "tls:"},
+		{"tls reload too fast", func(c *Config) { c.TLS.ReloadInterval = Duration(100 * time.Millisecond) }, "tls.reload_interval"},
+		{"redirect without tls", func(c *Config) { c.TLS.RedirectAddr = ":80" }, "tls.redirect_addr requires"},
+		{"small max message size", func(c *Config) { c.Limits.MaxMessageSize = 100 }, "limits.max_message_size"},
+		{"zero send buffer", func(c *Config) { c.Limits.ClientSendBuffer = 0 }, "limits.client_send_buffer"},
+		{"zero broadcast buffer", func(c *Config) { c.Limits.RoomBroadcastBuffer = 0 }, "limits.room_broadcast_buffer"},
+		{"negative max rooms", func(c *Config) { c.Limits.MaxRooms = -1 }, "limits.max_rooms"},
+		{"negative max clients", func(c *Config) { c.Limits.MaxClients = -1 }, "limits.max_clients"},
+		{"negative connections per ip", func(c *Config) { c.Limits.MaxConnectionsPerIP = -1 }, "limits.max_connections_per_ip"},
+		{"zero max players", func(c *Config) { c.Gameplay.MaxPlayers = 0 }, "gameplay.max_players"},
+		{"too many max players", func(c *Config) { c.Gameplay.MaxPlayers = maxConfigPlayers + 1 }, "gameplay.max_players"},
+		{"short game duration", func(c *Config) { c.Gameplay.GameDuration = Duration(5 * time.Second) }, "gameplay.game_duration"},
+		{"negative countdown", func(c *Config) { c.Gameplay.CountdownSeconds = -1 }, "gameplay.countdown_seconds"},
+		{"negative respawn delay", func(c *Config) { c.Gameplay.RespawnDelay = Duration(-time.Second) }, "gameplay.respawn_delay"},
+		{"zero max health", func(c *Config) { c.Gameplay.MaxHealth = 0 }, "gameplay.max_health"},
+		{"balance reload too fast", func(c *Config) { c.Balance.ReloadInterval = 0 }, "balance.reload_interval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validTestConfig(t)
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if err == nil {
+				t.Fatal("Validate accepted invalid config")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate error = %q, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigValidateReportsAllErrors(t *testing.T) {
+	cfg := validTestConfig(t)
+	cfg.Gameplay.MaxPlayers = 0
+	cfg.Gameplay.MaxHealth = 0
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "max_players") || !strings.Contains(err.Error(), "max_health") {
+		t.Fatalf("Validate error = %v, want both max_players and max_health", err)
+	}
+}
+
+func TestLoadConfigEnvOverridesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	file := `{
+		"listen_addr": ":9000",
+		"gameplay": {"max_players": 6, "game_duration": "90s"},
+		"limits": {"max_rooms": 10}
+	}`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GAME_MAX_PLAYERS", "8")
+	t.Setenv("GAME_DURATION", "2m")
+	t.Setenv("GAME_ALLOWED_ORIGINS", " https://a.example , ,https://b.example")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	// 파일 값
+	if cfg.ListenAddr != ":9000" || cfg.Limits.MaxRooms != 10 {
+		t.Errorf("file values not applied: listen_addr=%q max_rooms=%d", cfg.ListenAddr, cfg.Limits.MaxRooms)
+	}
+	// 환경 변수가 파일 값을 덮어씀
+	if cfg.Gameplay.MaxPlayers != 8 {
+		t.Errorf("max_players = %d, want env value 8", cfg.Gameplay.MaxPlayers)
+	}
+	if cfg.Gameplay.GameDuration != Duration(2*time.Minute) {
+		t.Errorf("game_duration = %s, want env value 2m", time.Duration(cfg.Gameplay.GameDuration))
+	}
+	if got := strings.Join(cfg.AllowedOrigins, ","); got != "https://a.example,https://b.example" {
+		t.Errorf("allowed_origins = %q", got)
+	}
+	// 둘 다 없으면 기본값
+	if cfg.Gameplay.MaxHealth != maxPlayerHealth {
+		t.Errorf("max_health = %d, want default %d", cfg.Gameplay.MaxHealth, maxPlayerHealth)
+	}
+}
+
+func TestLoadConfigRejectsBadInput(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"missing file", filepath.Join(dir, "missing.json"), nil, "read config"},
+		{"unknown field", write("unknown.json", `{"listen_adr": ":9000"}`), nil, "unknown field"},
+		{"numeric duration", write("duration.json", `{"gameplay": {"game_duration": 90}}`), nil, "duration must be a string"},
+		{"bad env integer", "", map[string]string{"GAME_MAX_PLAYERS": "many"}, "GAME_MAX_PLAYERS"},
+		{"bad env duration", "", map[string]string{"GAME_RESPAWN_DELAY": "soon"}, "GAME_RESPAWN_DELAY"},
+		{"bad env boolean", "", map[string]string{"GAME_BALANCE_APPLY_TO_RUNNING": "maybe"}, "GAME_BALANCE_APPLY_TO_RUNNING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			if _, err := LoadConfig(tt.path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadConfig error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsOriginAllowed(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AllowedOrigins = []string{"https://game.example"}
+
+	tests := []struct {
+		origin string
+		host   string
+		want   bool
+	}{
+		{"", "localhost:8080", true},
+		{"http://localhost:8080", "localhost:8080", true},
+		{"https://GAME.example", "other:8080", true},
+		{"https://evil.example", "localhost:8080", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.isOriginAllowed(tt.origin, tt.host); got != tt.want {
+			t.Errorf("isOriginAllowed(%q, %q) = %v, want %v", tt.origin, tt.host, got, tt.want)
+		}
+	}
+}
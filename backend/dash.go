@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	dashDistance   = 6.0                     // 대시 이동 거리
+	dashDuration   = 200 * time.Millisecond  // 대시 애니메이션, 이동 시간
+	dashCooldown   = 2000 * time.Millisecond // 대시 쿨타임
+	dashInvincible = 300 * time.Millisecond  // 대시 무적 시간 (이동 시간보다 조금 길게)
+
+	dashAnimation = "dash"
+)
+
+// 틱당 대시 이동 거리
+var dashStep = dashDistance / math.Ceil(float64(dashDuration)/float64(gameTickRate))
+
+// 대시 중인지
+func (ps *PlayerState) isDashing() bool {
+	return ps.DashRemaining > 0
+}
+
+// 남은 대시 쿨타임
+func (ps *PlayerState) dashCooldownLeft() time.Duration {
+	return max(0, dashCooldown-time.Since(ps.LastDashTime))
+}
+
+// 대시 액션 처리
+// g.mutex Lock 상태에서 호출
+func (g *Game) handleDashAction(client *Client, ps *PlayerState) {
+	// 카운트 다운 중에는 대시 불가
+	if !g.isRunning {
+		return
+	}
+	if !g.canDash(ps) {
+		g.logger.Debug("Dash not ready", "client_id", client.id)
+		return
+	}
+	g.startDash(ps)
+}
+
+// 대시를 지금 쓸 수 있는지
+// 쿨타임이 남았거나 다른 행동 중이면 불가
+func (g *Game) canDash(ps *PlayerState) bool {
+	return ps.dashCooldownLeft() == 0 && !ps.isBusy()
+}
+
+// 대시 시작
+// 이동 입력 방향으로, 입력이 없으면 바라보는 방향으로 대시
+// 플레이어 dash 액션과 봇이 공통으로 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) startDash(ps *PlayerState) {
+	// updateGameState의 이동 처리와 같은 축 변환 사용
+	dirX, dirZ := -ps.MoveStrafe, -ps.MoveForward
+	if magnitude := math.Hypot(dirX, dirZ); magnitude > 0 {
+		dirX, dirZ = dirX/magnitude, dirZ/magnitude
+	} else {
+		dirX, dirZ = math.Sin(ps.Yaw), math.Cos(ps.Yaw)
+	}
+
+	now := time.Now()
+	ps.LastDashTime = now
+	ps.DashDirX, ps.DashDirZ = dirX, dirZ
+	ps.DashRemaining = dashDistance
+
+	// 대시 무적, 더 긴 무적이 남아있으면 유지
+	if until := now.Add(dashInvincible); until.After(ps.InvincibleUntil) {
+		ps.InvincibleUntil = until
+	}
+	ps.IsInvincible = true
+
+	ps.CurrentAnimation = dashAnimation
+	ps.AnimationStart = now
+
+	g.logger.Debug("Dash", "client_id", ps.ID, "dir_x", dirX, "dir_z", dirZ)
+}
+
+// 대시 이동 처리
+// 틱마다 같은 거리씩 이동, 맵 경계에 닿으면 그 자리에서 멈춤
+// g.mutex Lock 상태에서 호출
+func (g *Game) updateDash(ps *PlayerState) {
+	step := math.Min(dashStep, ps.DashRemaining)
+	ps.DashRemaining -= step
+
+	x := ps.X + ps.DashDirX*step
+	z := ps.Z + ps.DashDirZ*step
+	ps.X = math.Max(-mapBoundary+1, math.Min(mapBoundary-1, x))
+	ps.Z = math.Max(-mapBoundary+1, math.Min(mapBoundary-1, z))
+	if ps.X != x || ps.Z != z {
+		ps.DashRemaining = 0
+	}
+}
+
+// 대시 중단 (죽음 등)
+func (ps *PlayerState) stopDash() {
+	ps.DashRemaining = 0
+}
@@ -0,0 +1,398 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	directoryHeartbeat = 5 * time.Second  // 로컬 방 정보 재등록 주기
+	directoryEntryTTL  = 20 * time.Second // 갱신되지 않은 방 정보 만료 시간 (노드 장애 대비)
+)
+
+// 방 디렉터리에 등록되는 방 정보
+type RoomDirectoryEntry struct {
+	RoomID         string    `json:"room_id"`
+	NodeID         string    `json:"node_id"`
+	NodeURL        string    `json:"node_url"` // 해당 노드의 WebSocket URL
+	CurrentPlayers int       `json:"current_players"`
+	MaxPlayers     int       `json:"max_players"`
+	State          RoomState `json:"state"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// 만료 여부
+func (e RoomDirectoryEntry) isStale(now time.Time) bool {
+	return now.Sub(e.UpdatedAt) > directoryEntryTTL
+}
+
+// 여러 서버 프로세스가 공유하는 방 디렉터리
+// 각 노드는 자신이 소유한 방만 등록/제거하고, 목록 조회는 전체 노드 대상
+// 구현체는 여러 고루틴에서 동시에 호출될 수 있어야 함
+type RoomDirectory interface {
+	// 방 정보 등록 또는 갱신
+	Publish(entry RoomDirectoryEntry) error
+	// 방 정보 제거
+	Remove(roomID string) error
+	// 방 정보 조회 (만료된 정보는 없는 것으로 취급)
+	Lookup(roomID string) (RoomDirectoryEntry, bool, error)
+	// 전체 방 목록 (만료된 정보 제외)
+	List() ([]RoomDirectoryEntry, error)
+}
+
+// 메모리 방 디렉터리
+// 한 프로세스 안에서만 공유되므로 테스트나 단일 프로세스 여러 서버 구성용
+type MemoryRoomDirectory struct {
+	entries map[string]RoomDirectoryEntry
+	mutex   sync.RWMutex
+}
+
+func NewMemoryRoomDirectory() *MemoryRoomDirectory {
+	return &MemoryRoomDirectory{entries: make(map[string]RoomDirectoryEntry)}
+}
+
+func (d *MemoryRoomDirectory) Publish(entry RoomDirectoryEntry) error {
+	d.mutex.Lock()
+	d.entries[entry.RoomID] = entry
+	d.mutex.Unlock()
+	return nil
+}
+
+func (d *MemoryRoomDirectory) Remove(roomID string) error {
+	d.mutex.Lock()
+	delete(d.entries, roomID)
+	d.mutex.Unlock()
+	return nil
+}
+
+func (d *MemoryRoomDirectory) Lookup(roomID string) (RoomDirectoryEntry, bool, error) {
+	d.mutex.RLock()
+	entry, ok := d.entries[roomID]
+	d.mutex.RUnlock()
+	if !ok || entry.isStale(time.Now()) {
+		return RoomDirectoryEntry{}, false, nil
+	}
+	return entry, true, nil
+}
+
+func (d *MemoryRoomDirectory) List() ([]RoomDirectoryEntry, error) {
+	now := time.Now()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	entries := make([]RoomDirectoryEntry, 0, len(d.entries))
+	for roomID, entry := range d.entries {
+		if entry.isStale(now) {
+			// 파일 디렉터리와 같이 만료된 정보 정리
+			delete(d.entries, roomID)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// 파일 기반 방 디렉터리
+// 같은 호스트의 여러 프로세스가 하나의 디렉터리를 공유
+// 방 하나당 JSON 파일 하나, 임시 파일 작성 후 rename으로 원자적 갱신
+type FileRoomDirectory struct {
+	dir string
+}
+
+func NewFileRoomDirectory(dir string) (*FileRoomDirectory, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create room directory %s: %w", dir, err)
+	}
+	return &FileRoomDirectory{dir: dir}, nil
+}
+
+func (d *FileRoomDirectory) entryPath(roomID string) string {
+	return filepath.Join(d.dir, roomID+".json")
+}
+
+func (d *FileRoomDirectory) Publish(entry RoomDirectoryEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.dir, ".tmp-"+entry.RoomID+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), d.entryPath(entry.RoomID))
+}
+
+func (d *FileRoomDirectory) Remove(roomID string) error {
+	err := os.Remove(d.entryPath(roomID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (d *FileRoomDirectory) Lookup(roomID string) (RoomDirectoryEntry, bool, error) {
+	if !safeIDPattern.MatchString(roomID) {
+		return RoomDirectoryEntry{}, false, nil
+	}
+	entry, err := d.readEntry(d.entryPath(roomID))
+	if errors.Is(err, os.ErrNotExist) {
+		return RoomDirectoryEntry{}, false, nil
+	}
+	if err != nil {
+		return RoomDirectoryEntry{}, false, err
+	}
+	if entry.isStale(time.Now()) {
+		return RoomDirectoryEntry{}, false, nil
+	}
+	return entry, true, nil
+}
+
+func (d *FileRoomDirectory) List() ([]RoomDirectoryEntry, error) {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]RoomDirectoryEntry, 0, len(files))
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		path := filepath.Join(d.dir, name)
+		entry, err := d.readEntry(path)
+		if err != nil {
+			// 다른 노드가 삭제 중일 수 있음
+			continue
+		}
+		if entry.isStale(now) {
+			// 장애로 정리되지 않은 노드의 방 정보 제거
+			os.Remove(path)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (d *FileRoomDirectory) readEntry(path string) (RoomDirectoryEntry, error) {
+	var entry RoomDirectoryEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+// 방 디렉터리 설정
+// nodeID는 노드 고유 이름, nodeURL은 다른 노드가 리다이렉트할 이 노드의 WebSocket URL
+// 서버 시작 직후, 방이 생성되기 전에 호출
+func (s *Server) SetRoomDirectory(directory RoomDirectory, nodeID, nodeURL string) {
+	s.mutex.Lock()
+	s.directory = directory
+	s.nodeID = nodeID
+	s.nodeURL = nodeURL
+	s.mutex.Unlock()
+
+	s.logger.Info("Room directory enabled", "node_id", nodeID, "node_url", nodeURL)
+	go s.runDirectory()
+}
+
+// 방 디렉터리 고루틴
+// 디렉터리는 파일 IO라 방, 게임, 서버 고루틴에서 직접 호출하지 않고 여기서만 갱신
+// 주기적으로, 또는 로컬 방 정보가 바뀌면 등록하고 다른 노드의 방 목록이 바뀌었으면 로비에 Broadcast
+func (s *Server) runDirectory() {
+	ticker := time.NewTicker(directoryHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		s.syncDirectory()
+		select {
+		case <-ticker.C:
+		case <-s.directorySync:
+		}
+	}
+}
+
+// 로컬 방 정보 등록 및 다른 노드의 방 목록 캐시 갱신
+func (s *Server) syncDirectory() {
+	if s.IsShuttingDown() {
+		// 종료 중에는 제거한 방 정보를 다시 등록하지 않음
+		return
+	}
+	// 제거된 방을 먼저 지운 뒤 남은 방 등록
+	// 제거 전에 찍은 스냅샷으로 등록한 정보가 있어도 다음 갱신에서 지워짐
+	s.mutex.Lock()
+	removed := s.removedRoomIDs
+	s.removedRoomIDs = nil
+	s.mutex.Unlock()
+	s.removeFromDirectory(removed...)
+
+	s.publishLocalRooms()
+	remoteRooms := s.remoteRooms()
+	sort.Slice(remoteRooms, func(i, j int) bool { return remoteRooms[i].ID < remoteRooms[j].ID })
+
+	s.mutex.Lock()
+	changed := !reflect.DeepEqual(remoteRooms, s.remoteRoomList)
+	if changed {
+		s.remoteRoomList = remoteRooms
+		s.remoteRoomIDs = make(map[string]bool, len(remoteRooms))
+		for _, item := range remoteRooms {
+			s.remoteRoomIDs[item.ID] = true
+		}
+	}
+	s.mutex.Unlock()
+
+	if changed {
+		s.broadcastRoomListToLobby()
+	}
+}
+
+// 디렉터리 갱신 요청
+// 이미 요청이 쌓여있으면 합쳐서 한 번만 처리, 호출한 고루틴은 막히지 않음
+func (s *Server) requestDirectorySync() {
+	select {
+	case s.directorySync <- struct{}{}:
+	default:
+	}
+}
+
+// 캐시된 다른 노드의 방 목록
+func (s *Server) cachedRemoteRooms() []RoomListItem {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.remoteRoomList
+}
+
+// 방 디렉터리와 노드 정보
+func (s *Server) directoryInfo() (RoomDirectory, string, string) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.directory, s.nodeID, s.nodeURL
+}
+
+// 로컬 방 정보를 디렉터리에 등록
+// 디렉터리 고루틴에서 호출
+func (s *Server) publishLocalRooms() {
+	directory, nodeID, nodeURL := s.directoryInfo()
+	if directory == nil {
+		return
+	}
+	now := time.Now()
+	for _, room := range s.roomSnapshot() {
+		room.mutex.RLock()
+		entry := RoomDirectoryEntry{
+			RoomID:         room.id,
+			NodeID:         nodeID,
+			NodeURL:        nodeURL,
+			CurrentPlayers: len(room.clients),
+			MaxPlayers:     room.maxPlayers,
+			State:          room.state,
+			UpdatedAt:      now,
+		}
+		room.mutex.RUnlock()
+
+		if err := directory.Publish(entry); err != nil {
+			room.logger.Error("Failed to publish room to directory", "error", err)
+		}
+	}
+}
+
+// 디렉터리에서 방 정보 제거
+// 디렉터리 고루틴, 서버 종료 시 호출 (s.mutex를 잡지 않은 상태)
+func (s *Server) removeFromDirectory(roomIDs ...string) {
+	directory, _, _ := s.directoryInfo()
+	if directory == nil {
+		return
+	}
+	for _, roomID := range roomIDs {
+		if err := directory.Remove(roomID); err != nil {
+			s.logger.Error("Failed to remove room from directory", "room_id", roomID, "error", err)
+		}
+	}
+}
+
+// 다른 노드의 방 목록
+// 디렉터리 고루틴에서 호출, 그 외에는 cachedRemoteRooms 사용
+func (s *Server) remoteRooms() []RoomListItem {
+	directory, nodeID, _ := s.directoryInfo()
+	if directory == nil {
+		return nil
+	}
+	entries, err := directory.List()
+	if err != nil {
+		s.logger.Error("Failed to list room directory", "error", err)
+		return nil
+	}
+
+	items := make([]RoomListItem, 0, len(entries))
+	for _, entry := range entries {
+		if entry.NodeID == nodeID {
+			continue
+		}
+		items = append(items, RoomListItem{
+			ID:             entry.RoomID,
+			CurrentPlayers: entry.CurrentPlayers,
+			MaxPlayers:     entry.MaxPlayers,
+			State:          entry.State,
+			NodeID:         entry.NodeID,
+		})
+	}
+	return items
+}
+
+// 다른 노드에 있는 방 조회
+// 찾으면 리다이렉트 메세지 전송 후 true 반환
+func (s *Server) redirectToRemoteRoom(client *Client, roomID string) bool {
+	directory, nodeID, _ := s.directoryInfo()
+	if directory == nil {
+		return false
+	}
+
+	entry, ok, err := directory.Lookup(roomID)
+	if err != nil {
+		s.logger.Error("Room directory lookup failed", "room_id", roomID, "error", err)
+		return false
+	}
+	if !ok || entry.NodeID == nodeID {
+		return false
+	}
+
+	client.logger.Info("Redirecting client to remote room", "room_id", roomID, "node_id", entry.NodeID)
+	msg := Message{Type: MessageTypeRoomRedirect, Payload: RoomRedirectPayload{
+		RoomID: roomID,
+		NodeID: entry.NodeID,
+		WsURL:  entry.NodeURL,
+	}}
+	payloadBytes, _ := json.Marshal(msg)
+	client.send <- payloadBytes
+	return true
+}
+
+// 다른 노드에서 이미 사용 중인 방 ID인지 확인
+// 디렉터리를 직접 읽지 않고 캐시로 확인 (최대 directoryHeartbeat만큼 늦을 수 있음)
+// s.mutex를 잡지 않은 상태에서 호출
+func (s *Server) isRoomIDTakenRemotely(roomID string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.remoteRoomIDs[roomID]
+}
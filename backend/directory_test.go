@@ -0,0 +1,191 @@
+package backend
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// 디렉터리를 사용하는 단일 노드 서버 (고루틴 없이 직접 호출)
+func newDirectoryTestServer(directory RoomDirectory, nodeID string) *Server {
+	return &Server{
+		clients:   make(map[string]*Client),
+		rooms:     make(map[string]*Room),
+		logger:    discardLogger,
+		directory: directory,
+		nodeID:    nodeID,
+		nodeURL:   "ws://" + nodeID + "/ws",
+	}
+}
+
+func directoryEntry(roomID, nodeID string, updatedAt time.Time) RoomDirectoryEntry {
+	return RoomDirectoryEntry{
+		RoomID:         roomID,
+		NodeID:         nodeID,
+		NodeURL:        "ws://" + nodeID + "/ws",
+		CurrentPlayers: 1,
+		MaxPlayers:     4,
+		State:          RoomStateWaiting,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+func TestRoomDirectoryImplementations(t *testing.T) {
+	implementations := []struct {
+		name      string
+		directory func(t *testing.T) RoomDirectory
+	}{
+		{"memory", func(t *testing.T) RoomDirectory { return NewMemoryRoomDirectory() }},
+		{"file", func(t *testing.T) RoomDirectory {
+			directory, err := NewFileRoomDirectory(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewFileRoomDirectory: %v", err)
+			}
+			return directory
+		}},
+	}
+
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			directory := impl.directory(t)
+			now := time.Now()
+
+			fresh := directoryEntry("FRESH1", "node-a", now)
+			stale := directoryEntry("STALE1", "node-b", now.Add(-directoryEntryTTL-time.Second))
+			for _, entry := range []RoomDirectoryEntry{fresh, stale} {
+				if err := directory.Publish(entry); err != nil {
+					t.Fatalf("Publish(%s): %v", entry.RoomID, err)
+				}
+			}
+
+			entry, ok, err := directory.Lookup("FRESH1")
+			if err != nil || !ok {
+				t.Fatalf("Lookup(FRESH1) = ok %v, err %v", ok, err)
+			}
+			if entry.NodeURL != fresh.NodeURL || entry.CurrentPlayers != fresh.CurrentPlayers {
+				t.Fatalf("Lookup(FRESH1) = %+v, want %+v", entry, fresh)
+			}
+			if _, ok, _ := directory.Lookup("STALE1"); ok {
+				t.Fatal("Lookup returned an expired entry")
+			}
+			if _, ok, _ := directory.Lookup("NOPE01"); ok {
+				t.Fatal("Lookup returned an unknown room")
+			}
+
+			entries, err := directory.List()
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(entries) != 1 || entries[0].RoomID != "FRESH1" {
+				t.Fatalf("List = %+v, want only FRESH1", entries)
+			}
+
+			if err := directory.Remove("FRESH1"); err != nil {
+				t.Fatalf("Remove: %v", err)
+			}
+			if _, ok, _ := directory.Lookup("FRESH1"); ok {
+				t.Fatal("Lookup returned a removed entry")
+			}
+			if err := directory.Remove("FRESH1"); err != nil {
+				t.Fatalf("Remove of missing entry: %v", err)
+			}
+		})
+	}
+}
+
+func TestRemoteRoomsExcludesLocalNode(t *testing.T) {
+	directory := NewMemoryRoomDirectory()
+	now := time.Now()
+	directory.Publish(directoryEntry("LOCAL1", "node-a", now))
+	directory.Publish(directoryEntry("REMOT1", "node-b", now))
+	directory.Publish(directoryEntry("REMOT2", "node-c", now))
+
+	s := newDirectoryTestServer(directory, "node-a")
+	rooms := s.remoteRooms()
+	if len(rooms) != 2 {
+		t.Fatalf("remoteRooms = %+v, want 2 rooms", rooms)
+	}
+	for _, room := range rooms {
+		if room.NodeID == "node-a" {
+			t.Fatalf("remoteRooms included local room %s", room.ID)
+		}
+	}
+}
+
+func TestRedirectToRemoteRoom(t *testing.T) {
+	directory := NewMemoryRoomDirectory()
+	now := time.Now()
+	directory.Publish(directoryEntry("LOCAL1", "node-a", now))
+	directory.Publish(directoryEntry("REMOT1", "node-b", now))
+	s := newDirectoryTestServer(directory, "node-a")
+
+	tests := []struct {
+		name   string
+		roomID string
+		want   bool
+	}{
+		{"remote room", "REMOT1", true},
+		{"local node room", "LOCAL1", false},
+		{"unknown room", "NOPE01", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{id: "c1", send: make(chan []byte, 1), logger: discardLogger}
+			if got := s.redirectToRemoteRoom(client, tt.roomID); got != tt.want {
+				t.Fatalf("redirectToRemoteRoom(%s) = %v, want %v", tt.roomID, got, tt.want)
+			}
+			if !tt.want {
+				if len(client.send) != 0 {
+					t.Fatal("message sent for a room that was not redirected")
+				}
+				return
+			}
+
+			var msg struct {
+				Type    MessageType         `json:"type"`
+				Payload RoomRedirectPayload `json:"payload"`
+			}
+			if err := json.Unmarshal(<-client.send, &msg); err != nil {
+				t.Fatalf("unmarshal redirect: %v", err)
+			}
+			want := RoomRedirectPayload{RoomID: "REMOT1", NodeID: "node-b", WsURL: "ws://node-b/ws"}
+			if msg.Type != MessageTypeRoomRedirect || msg.Payload != want {
+				t.Fatalf("redirect = %s %+v, want %s %+v", msg.Type, msg.Payload, MessageTypeRoomRedirect, want)
+			}
+		})
+	}
+}
+
+func TestSyncDirectory(t *testing.T) {
+	directory := NewMemoryRoomDirectory()
+	directory.Publish(directoryEntry("REMOT1", "node-b", time.Now()))
+	directory.Publish(directoryEntry("GONE01", "node-a", time.Now()))
+
+	s := newDirectoryTestServer(directory, "node-a")
+	s.rooms["LOCAL1"] = &Room{id: "LOCAL1", clients: make(map[*Client]bool), maxPlayers: 4, state: RoomStateWaiting, logger: discardLogger}
+	s.removedRoomIDs = []string{"GONE01"}
+
+	s.syncDirectory()
+
+	// 로컬 방 등록, 제거된 방 삭제
+	if entry, ok, _ := directory.Lookup("LOCAL1"); !ok || entry.NodeID != "node-a" || entry.NodeURL != "ws://node-a/ws" {
+		t.Fatalf("Lookup(LOCAL1) = %+v, %v", entry, ok)
+	}
+	if _, ok, _ := directory.Lookup("GONE01"); ok {
+		t.Fatal("removed room is still in the directory")
+	}
+
+	// 방 목록은 로컬 방과 캐시된 다른 노드의 방을 합침
+	rooms := s.buildRoomList()
+	if len(rooms) != 2 {
+		t.Fatalf("buildRoomList = %+v, want LOCAL1 and REMOT1", rooms)
+	}
+	if !s.isRoomIDTakenRemotely("REMOT1") || s.isRoomIDTakenRemotely("LOCAL1") {
+		t.Fatal("remote room ID cache does not match the directory")
+	}
+}
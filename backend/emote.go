@@ -0,0 +1,157 @@
+package backend
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	emoteCooldown = 3000 * time.Millisecond // 이모트 쿨타임
+	emoteDuration = 1500 * time.Millisecond // 이모트 애니메이션 지속 시간
+
+	pingCooldown      = 1000 * time.Millisecond // 핑 쿨타임
+	pingLifetime      = 3000 * time.Millisecond // 핑 마커 유지 시간
+	maxPingsPerPlayer = 3                       // 플레이어당 동시에 유지되는 핑 최대 개수
+
+	emoteAnimationPrefix = "emote_"
+)
+
+// 허용된 이모트 목록
+// 클라이언트가 보낸 값은 이 목록에 있을 때만 애니메이션으로 사용
+var allowedEmotes = map[string]bool{
+	"wave":  true,
+	"taunt": true,
+	"cheer": true,
+	"laugh": true,
+}
+
+// 허용된 핑 종류
+var allowedPingKinds = map[string]bool{
+	"default": true,
+	"danger":  true,
+	"target":  true,
+	"help":    true,
+}
+
+// 맵 위 핑 마커
+type PingMarker struct {
+	ID        string    `json:"id"`
+	OwnerID   string    `json:"owner_id"`
+	Kind      string    `json:"kind"`
+	X         float64   `json:"x"`
+	Z         float64   `json:"z"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// 이모트 애니메이션 여부
+func isEmoteAnimation(animation string) bool {
+	return strings.HasPrefix(animation, emoteAnimationPrefix)
+}
+
+// 이모트 액션 처리
+// g.mutex Lock 상태에서 호출
+func (g *Game) handleEmoteAction(client *Client, ps *PlayerState, data map[string]interface{}) {
+	emote, _ := data["emote"].(string)
+	if !allowedEmotes[emote] {
+		g.logger.Debug("Unknown emote ignored", "client_id", client.id, "emote", emote)
+		return
+	}
+
+	// 이모트 쿨타임 체크
+	if time.Since(ps.LastEmoteTime) < emoteCooldown {
+		return
+	}
+
+	// 다른 행동 중에는 이모트 불가
+	if ps.isBusy() {
+		return
+	}
+
+	now := time.Now()
+	ps.LastEmoteTime = now
+	ps.CurrentAnimation = emoteAnimationPrefix + emote
+	ps.AnimationStart = now
+}
+
+// 핑 액션 처리
+// g.mutex Lock 상태에서 호출
+func (g *Game) handlePingAction(client *Client, ps *PlayerState, data map[string]interface{}) {
+	x, okX := data["x"].(float64)
+	z, okZ := data["z"].(float64)
+	if !okX || !okZ || math.IsNaN(x) || math.IsNaN(z) {
+		g.logger.Debug("Ping without valid position ignored", "client_id", client.id)
+		return
+	}
+
+	kind, _ := data["kind"].(string)
+	if kind == "" {
+		kind = "default"
+	}
+	if !allowedPingKinds[kind] {
+		g.logger.Debug("Unknown ping kind ignored", "client_id", client.id, "kind", kind)
+		return
+	}
+
+	// 핑 쿨타임 체크
+	if time.Since(ps.LastPingTime) < pingCooldown {
+		return
+	}
+
+	// 플레이어당 핑 개수 제한, 가장 오래된 핑 제거
+	var oldest *PingMarker
+	count := 0
+	for _, ping := range g.pings {
+		if ping.OwnerID != client.id {
+			continue
+		}
+		count++
+		if oldest == nil || ping.CreatedAt.Before(oldest.CreatedAt) {
+			oldest = ping
+		}
+	}
+	if count >= maxPingsPerPlayer && oldest != nil {
+		delete(g.pings, oldest.ID)
+	}
+
+	now := time.Now()
+	g.pingCounter++
+	pingID := fmt.Sprintf("ping_%s_%d", client.id, g.pingCounter)
+
+	// 맵 Boundary 안으로 보정
+	g.pings[pingID] = &PingMarker{
+		ID:        pingID,
+		OwnerID:   client.id,
+		Kind:      kind,
+		X:         math.Max(-mapBoundary, math.Min(mapBoundary, x)),
+		Z:         math.Max(-mapBoundary, math.Min(mapBoundary, z)),
+		Color:     ps.Color,
+		CreatedAt: now,
+		ExpiresAt: now.Add(pingLifetime),
+	}
+	ps.LastPingTime = now
+}
+
+// 만료된 핑 제거
+// g.mutex Lock 상태에서 호출
+func (g *Game) updatePings() {
+	now := time.Now()
+	for pingID, ping := range g.pings {
+		if now.After(ping.ExpiresAt) {
+			delete(g.pings, pingID)
+		}
+	}
+}
+
+// 현재 유지 중인 핑 목록
+// g.mutex RLock 이상 상태에서 호출
+func (g *Game) activePings() []*PingMarker {
+	pings := make([]*PingMarker, 0, len(g.pings))
+	for _, ping := range g.pings {
+		pings = append(pings, ping)
+	}
+	return pings
+}
@@ -0,0 +1,237 @@
+package backend
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// 던지기
+	throwCooldown   = 4000 * time.Millisecond // 던지기 쿨타임
+	throwDuration   = 400 * time.Millisecond  // 던지기 애니메이션 지속 시간
+	throwSpeed      = 0.8                     // 틱당 이동 거리
+	throwLifetime   = 1000 * time.Millisecond // 날아가는 시간 (사거리 = 속도 x 틱 수)
+	throwRadius     = 0.8                     // 충돌 반경
+	throwSpawnAhead = 1.0                     // 던진 플레이어 앞쪽 생성 거리
+	throwAnimation  = "throw"
+
+	tearCloudSlowLinger = 1000 * time.Millisecond // 눈물 구름을 벗어난 뒤에도 유지되는 감속 시간
+)
+
+// 공격 개체 종류
+type AttackEntityKind string
+
+const (
+	AttackEntityProjectile AttackEntityKind = "projectile" // 틱마다 이동, 처음 닿은 대상에게 피해 후 사라짐
+	AttackEntityZone       AttackEntityKind = "zone"       // 제자리에 유지, 안에 있는 대상에게 주기적으로 효과
+)
+
+// 공격 개체 (투사체, 지속 범위 효과)
+// 틱마다 플레이어와 충돌 판정, 상태 broadcast에 포함
+type AttackEntity struct {
+	ID        string           `json:"id"`
+	Kind      AttackEntityKind `json:"kind"`
+	Type      string           `json:"type"`               // 클라이언트 표시용 종류 (thrown_vegetable, tear_cloud 등)
+	OwnerID   string           `json:"owner_id,omitempty"` // 비어있으면 환경 (함정, 위험 지역)
+	Asset     string           `json:"asset,omitempty"`    // 던진 채소 모델
+	X         float64          `json:"x"`
+	Z         float64          `json:"z"`
+	VelocityX float64          `json:"velocity_x,omitempty"` // 틱당 이동 거리
+	VelocityZ float64          `json:"velocity_z,omitempty"`
+	Radius    float64          `json:"radius"`
+	Color     string           `json:"color,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	ExpiresAt time.Time        `json:"expires_at"`
+
+	owner        *PlayerState         // nil이면 환경
+	lifetime     time.Duration        // 생성 시 ExpiresAt 계산용
+	damage       int                  // 닿았을 때 피해
+	breaksBlock  bool                 // 막기를 깨는지
+	tickInterval time.Duration        // 지속 범위 효과의 같은 대상 재적용 주기
+	slowDuration time.Duration        // 닿은 대상 감속 시간
+	lastApplied  map[string]time.Time // 대상별 마지막 적용 시각 (지속 범위 효과)
+}
+
+// 공격 개체 추가
+// ID, 생성, 만료 시각, 소유자 정보 채움
+// g.mutex Lock 상태에서 호출
+func (g *Game) addAttackEntity(entity *AttackEntity) *AttackEntity {
+	now := time.Now()
+	g.attackCounter++
+	ownerID := "env"
+	if entity.owner != nil {
+		ownerID = entity.owner.ID
+		entity.OwnerID = entity.owner.ID
+		if entity.Color == "" {
+			entity.Color = entity.owner.Color
+		}
+	}
+	entity.ID = fmt.Sprintf("%s_%s_%d", entity.Kind, ownerID, g.attackCounter)
+	entity.CreatedAt = now
+	entity.ExpiresAt = now.Add(entity.lifetime)
+	entity.lastApplied = make(map[string]time.Time)
+
+	g.attackEntities[entity.ID] = entity
+	return entity
+}
+
+// 공격 개체가 대상에게 영향을 주는지
+// 소유자 자신은 제외, 아군은 아군 공격 허용 시 피해만 받음 (감속 등 방해 효과 제외)
+func (g *Game) entityAffects(entity *AttackEntity, ps *PlayerState) bool {
+	if !ps.IsConnected || !ps.IsAlive || ps == entity.owner {
+		return false
+	}
+	if entity.owner != nil && g.isTeammate(entity.owner, ps) {
+		return entity.damage > 0 && g.settings.FriendlyFire
+	}
+	return true
+}
+
+// 공격 개체 이동, 충돌 처리, 만료 제거
+// g.mutex Lock 상태에서 호출
+func (g *Game) updateAttackEntities() {
+	now := time.Now()
+	for id, entity := range g.attackEntities {
+		if now.After(entity.ExpiresAt) {
+			delete(g.attackEntities, id)
+			continue
+		}
+
+		switch entity.Kind {
+		case AttackEntityProjectile:
+			if g.updateProjectile(entity, now) {
+				delete(g.attackEntities, id)
+			}
+		case AttackEntityZone:
+			g.updateZone(entity, now)
+		}
+	}
+}
+
+// 투사체 이동 및 충돌
+// 사라져야 하면 true
+func (g *Game) updateProjectile(entity *AttackEntity, now time.Time) bool {
+	entity.X += entity.VelocityX
+	entity.Z += entity.VelocityZ
+
+	// 맵 밖으로 나가면 제거
+	if math.Abs(entity.X) > mapBoundary || math.Abs(entity.Z) > mapBoundary {
+		return true
+	}
+
+	for _, ps := range g.players {
+		if !g.entityAffects(entity, ps) || math.Hypot(ps.X-entity.X, ps.Z-entity.Z) > entity.Radius {
+			continue
+		}
+		// 무적 상태인 대상은 통과
+		if ps.IsInvincible {
+			continue
+		}
+		// 막혀도 투사체는 사라짐
+		g.damagePlayer(entity.owner, ps, damageInfo{
+			amount:      entity.damage,
+			sourceX:     entity.X - entity.VelocityX,
+			sourceZ:     entity.Z - entity.VelocityZ,
+			breaksBlock: entity.breaksBlock,
+			source:      entity.Type,
+		}, now)
+		return true
+	}
+	return false
+}
+
+// 지속 범위 효과 적용
+// 같은 대상에게는 tickInterval마다 한 번씩 적용
+func (g *Game) updateZone(entity *AttackEntity, now time.Time) {
+	for _, ps := range g.players {
+		if !g.entityAffects(entity, ps) || math.Hypot(ps.X-entity.X, ps.Z-entity.Z) > entity.Radius {
+			continue
+		}
+		if last, ok := entity.lastApplied[ps.ID]; ok && now.Sub(last) < entity.tickInterval {
+			continue
+		}
+		entity.lastApplied[ps.ID] = now
+
+		if entity.slowDuration > 0 && (entity.owner == nil || !g.isTeammate(entity.owner, ps)) {
+			if until := now.Add(entity.slowDuration); until.After(ps.SlowedUntil) {
+				ps.SlowedUntil = until
+			}
+		}
+		if entity.damage > 0 {
+			g.damagePlayer(entity.owner, ps, damageInfo{
+				amount:      entity.damage,
+				sourceX:     entity.X,
+				sourceZ:     entity.Z,
+				breaksBlock: entity.breaksBlock,
+				source:      entity.Type,
+			}, now)
+		}
+	}
+}
+
+// 현재 공격 개체 목록
+// g.mutex RLock 이상 상태에서 호출
+func (g *Game) activeAttackEntities() []*AttackEntity {
+	entities := make([]*AttackEntity, 0, len(g.attackEntities))
+	for _, entity := range g.attackEntities {
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// 남은 던지기 쿨타임
+func (ps *PlayerState) throwCooldownLeft() time.Duration {
+	return max(0, throwCooldown-time.Since(ps.LastThrowTime))
+}
+
+// 던지기 액션 처리
+// 방향은 click과 같은 검증 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) handleThrowAction(client *Client, ps *PlayerState, actionData map[string]interface{}) {
+	// 카운트 다운 중에는 던지기 불가
+	if !g.isRunning {
+		return
+	}
+	if ps.throwCooldownLeft() > 0 || ps.isBusy() {
+		g.logger.Debug("Throw not ready", "client_id", client.id)
+		return
+	}
+
+	directionData, _ := actionData["direction"].(map[string]interface{})
+	dirX, okX := directionData["x"].(float64)
+	dirZ, okZ := directionData["z"].(float64)
+	if !okX || !okZ {
+		return
+	}
+	dirX, dirZ, ok := g.validateAttackDirection(ps, dirX, dirZ, actionData)
+	if !ok {
+		return
+	}
+	g.throwVegetable(ps, dirX, dirZ)
+}
+
+// 채소 던지기
+// 던진 플레이어의 캐릭터 모델을 투사체로 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) throwVegetable(ps *PlayerState, dirX, dirZ float64) {
+	now := time.Now()
+	ps.LastThrowTime = now
+	ps.CurrentAnimation = throwAnimation
+	ps.AnimationStart = now
+
+	entity := g.addAttackEntity(&AttackEntity{
+		Kind:      AttackEntityProjectile,
+		Type:      "thrown_vegetable",
+		Asset:     ps.Asset,
+		X:         ps.X + dirX*throwSpawnAhead,
+		Z:         ps.Z + dirZ*throwSpawnAhead,
+		VelocityX: dirX * throwSpeed,
+		VelocityZ: dirZ * throwSpeed,
+		Radius:    throwRadius,
+		owner:     ps,
+		lifetime:  throwLifetime,
+		damage:    g.balance.HammerDamage,
+	})
+	g.logger.Debug("Vegetable thrown", "client_id", ps.ID, "entity_id", entity.ID, "dir_x", dirX, "dir_z", dirZ)
+}
@@ -0,0 +1,62 @@
+package backend
+
+import "time"
+
+// 게임 이벤트 종류
+type GameEventType string
+
+const (
+	GameEventHit        GameEventType = "hit"        // 피해를 입었지만 살아있음
+	GameEventKill       GameEventType = "kill"       // 처치 (attacker_id가 비어있으면 환경에 의한 죽음)
+	GameEventRespawn    GameEventType = "respawn"    // 부활
+	GameEventStreak     GameEventType = "streak"     // 연속 처치
+	GameEventPickup     GameEventType = "pickup"     // 아이템 획득 (아이템 추가 시 사용)
+	GameEventDisconnect GameEventType = "disconnect" // 게임 중 연결 끊김
+
+	streakEventMin = 3 // 이 수 이상 연속 처치부터 streak 이벤트
+)
+
+// 피해 원인 (킬 피드 표시용)
+const (
+	damageSourceHammer = "hammer"
+	damageSourceHeavy  = "heavy_attack"
+	damageSourceArena  = "arena"
+)
+
+// 게임 이벤트
+// 킬 피드, 효과음용, 틱마다 모아서 game_event로 전송
+type GameEvent struct {
+	Type       GameEventType `json:"type"`
+	AttackerID string        `json:"attacker_id,omitempty"`
+	VictimID   string        `json:"victim_id,omitempty"`
+	PlayerID   string        `json:"player_id,omitempty"` // 대상이 한 명인 이벤트 (respawn, streak, pickup, disconnect)
+	Source     string        `json:"source,omitempty"`    // 피해 원인 (hammer, heavy_attack, 능력 ID, 공격 개체 종류, arena 등)
+	Damage     int           `json:"damage,omitempty"`
+	Streak     int           `json:"streak,omitempty"`
+	Points     int           `json:"points,omitempty"`      // kill: 처치한 플레이어가 얻은 점수 (추가 점수 포함)
+	Bonuses    []string      `json:"bonuses,omitempty"`     // kill: 적용된 추가 점수 (streak, bounty, revenge)
+	PointsLost int           `json:"points_lost,omitempty"` // kill: 죽은 플레이어가 잃은 점수
+	Timestamp  int64         `json:"timestamp"`             // 서버 시간 (Unix ms)
+}
+
+// 이벤트 추가
+// g.mutex Lock 상태에서 호출
+func (g *Game) emitEvent(event GameEvent, now time.Time) {
+	event.Timestamp = now.UnixMilli()
+	g.events = append(g.events, event)
+}
+
+// 쌓인 이벤트 전송
+// 이벤트가 없으면 전송하지 않음
+func (g *Game) broadcastEvents() {
+	g.mutex.Lock()
+	events := g.events
+	g.events = nil
+	g.mutex.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+	msg := Message{Type: MessageTypeGameEvent, Payload: GameEventPayload{Events: events}}
+	g.broadcastAndRecord(msg)
+}
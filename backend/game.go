@@ -1,8 +1,9 @@
 package backend
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"math"
 	"sync"
 	"time"
@@ -11,26 +12,26 @@ import (
 const (
 	gameFPS             = 30
 	gameTickRate        = time.Second / gameFPS
-	countdownSeconds    = 5
-	defaultGameDuration = 120 * time.Second
-	playerSpeed         = 0.5
+	countdownSeconds    = 5                 // 설정 기본값
+	defaultGameDuration = 120 * time.Second // 설정 기본값
+	playerSpeed         = 0.5               // 밸런스 기본값
 
 	// 맵 설정
 	mapSize     = 40.0 // 맵 크기
 	mapBoundary = 20.0 // 맵 경계
 
 	// 망치 설정
-	hammerRange     = 3.0             // 망치의 공격 범위
-	hammerDamage    = 1               // 망치 한 번 때릴 때 데미지
+	hammerRange     = 3.0             // 망치의 공격 범위 (밸런스 기본값)
+	hammerDamage    = 1               // 망치 한 번 때릴 때 데미지 (밸런스 기본값)
 	maxPlayerHealth = 3               // 플레이어 최대 체력
 	respawnDelay    = 3 * time.Second // 죽고 부활까지 걸리는 시간
 
-	hammerCooldown     = 500 * time.Millisecond  // 망치 공격 쿨타임
+	hammerCooldown     = 500 * time.Millisecond  // 망치 공격 쿨타임 (밸런스 기본값)
 	hammerDuration     = 500 * time.Millisecond  // 망치 애니메이션 지속 시간
 	hitDuration        = 1000 * time.Millisecond // 맞기 애니메이션 지속 시간
 	deathDuration      = 3000 * time.Millisecond // 죽음 애니메이션 지속 시간
 	respawnDuration    = 500 * time.Millisecond  // 부활 애니메이션 지속 시간
-	invincibleDuration = 2000 * time.Millisecond // 무적 상태 지속 시간
+	invincibleDuration = 2000 * time.Millisecond // 무적 상태 지속 시간 (밸런스 기본값)
 )
 
 // 공격 정보
@@ -43,22 +44,42 @@ type HammerAttack struct {
 	DirectionZ float64   `json:"direction_z"`
 	CreatedAt  time.Time `json:"created_at"`
 	HitTime    time.Time `json:"hit_time"` // 실제 타격 판정 시간 (망치 애니메이션 딜레이 보정용)
+	Range      float64   `json:"range"`    // 공격자 캐릭터의 망치 범위 (충전 공격은 더 넓음)
+	Damage     int       `json:"damage"`
+	Heavy      bool      `json:"heavy"` // 충전 공격 (막기를 깸)
 	Color      string    `json:"color"`
 }
 
 // 게임 상태
 type Game struct {
-	room          *Room
-	players       map[*Client]*PlayerState
-	hammerAttacks map[string]*HammerAttack
-	startTime     time.Time
-	duration      time.Duration
-	ticker        *time.Ticker
-	isReady       bool
-	isRunning     bool
-	quit          chan struct{}
-	mutex         sync.RWMutex
-	attackCounter int
+	room           *Room
+	players        map[*Client]*PlayerState
+	hammerAttacks  map[string]*HammerAttack
+	attackEntities map[string]*AttackEntity // 투사체, 지속 범위 효과
+	startTime      time.Time
+	duration       time.Duration
+	ticker         *time.Ticker
+	isReady        bool
+	isRunning      bool
+	quit           chan struct{}
+	mutex          sync.RWMutex
+	attackCounter  int
+	tick           uint64
+	recorder       *replayRecorder // nil이면 녹화 안 함
+	settings       RoomSettings
+	gameplay       GameplayConfig // 서버 설정의 게임 기본값
+	balance        *Balance       // 현재 적용 중인 밸런스 (교체만 하고 수정하지 않음)
+	pendingBalance *Balance       // 다음 틱에 적용할 밸런스
+	balanceNotice  *Message       // 적용 후 아직 보내지 않은 balance_updated
+	bots           map[*Client]*botBrain
+	pings          map[string]*PingMarker
+	events         []GameEvent // 다음 broadcast에 보낼 이벤트
+	pingCounter    int
+	arena          *ArenaState // nil이면 경기장 축소 없음 (또는 아직 시작 전)
+	hazards        map[string]*Hazard
+	hazardCounter  int
+	nextHazardAt   time.Time
+	logger         *slog.Logger // room_id 포함
 }
 
 // 게임 내 플레이어의 상태
@@ -73,6 +94,12 @@ type PlayerState struct {
 	Pitch    float64 `json:"pitch"`
 	Score    int     `json:"score"`
 	Asset    string  `json:"asset"`
+	Team     string  `json:"team,omitempty"`
+
+	// 캐릭터
+	Character string         `json:"character"`
+	Stats     CharacterStats `json:"stats"`
+	Ability   string         `json:"ability"` // 고유 능력 ID
 
 	// 체력 시스템
 	Health       int       `json:"health"`        // 현재 체력
@@ -93,21 +120,67 @@ type PlayerState struct {
 	LastActionTime  time.Time
 	LastAttackTime  time.Time // 마지막 공격 시간
 	LastHitTime     time.Time // 마지막 피격 시간
+	LastEmoteTime   time.Time // 마지막 이모트 시간
+	LastPingTime    time.Time // 마지막 핑 시간
+	LastAbilityTime time.Time // 마지막 능력 사용 시간
+	LastDashTime    time.Time // 마지막 대시 시간
+	LastThrowTime   time.Time // 마지막 던지기 시간
+	RecoverUntil    time.Time // 충전 공격 후딜이 끝나는 시간
 	InvincibleUntil time.Time // 무적 상태 지속 시간
-	IsConnected     bool
+	SlowedUntil     time.Time // 감속 상태 지속 시간
+	LastArenaDamage time.Time // 마지막 안전 구역 밖 피해 시간
+	AbilityDirX     float64   // 능력 사용 방향
+	AbilityDirZ     float64
+	DashDirX        float64 // 대시 방향
+	DashDirZ        float64
+	DashRemaining   float64 // 남은 대시 이동 거리
+
+	// 충전 공격, 막기
+	IsCharging       bool
+	ChargeStart      time.Time
+	IsBlocking       bool
+	GuardBrokenUntil time.Time // 막기가 깨져서 다시 막을 수 없는 시간
+	IsConnected      bool
+	IsBot            bool
+
+	// 전투 기록
+	Combat       CombatStats `json:"-"`
+	lastKillerID string      // 마지막으로 나를 처치한 플레이어 (복수 점수용)
+
+	// 입력 검증, 의심 점수
+	antiCheat antiCheatState
 }
 
 // 새 게임 생성
 func NewGame(room *Room, gamePlayers []*Client) *Game {
 	g := &Game{
-		room:          room,
-		players:       make(map[*Client]*PlayerState),
-		hammerAttacks: make(map[string]*HammerAttack),
-		duration:      defaultGameDuration,
-		quit:          make(chan struct{}),
-		isReady:       false,
-		isRunning:     false,
-		attackCounter: 0,
+		room:           room,
+		players:        make(map[*Client]*PlayerState),
+		hammerAttacks:  make(map[string]*HammerAttack),
+		attackEntities: make(map[string]*AttackEntity),
+		pings:          make(map[string]*PingMarker),
+		hazards:        make(map[string]*Hazard),
+		duration:       time.Duration(room.server.config.Gameplay.GameDuration),
+		quit:           make(chan struct{}),
+		isReady:        false,
+		isRunning:      false,
+		attackCounter:  0,
+		settings:       room.settings,
+		gameplay:       room.server.config.Gameplay,
+		balance:        room.server.currentBalance(),
+		bots:           make(map[*Client]*botBrain),
+		logger:         room.logger,
+	}
+
+	// 리플레이 녹화 시작
+	if room.server.replays != nil {
+		recorder, err := room.server.replays.newRecorder(room.id)
+		if err != nil {
+			g.logger.Error("Failed to start replay recording", "error", err)
+		} else {
+			g.recorder = recorder
+			g.recorder.addBalanceVersion(g.balance.Version)
+		}
 	}
 
 	// 원형 배치
@@ -120,9 +193,9 @@ func NewGame(room *Room, gamePlayers []*Client) *Game {
 		x := spawnRadius * math.Cos(angle)
 		z := spawnRadius * math.Sin(angle)
 
-		// client 정보에서 character 정보 추출
-		// TODO: .glb 빼기
-		assetFile := client.character + ".glb"
+		// 캐릭터 목록에서 모델 파일, 능력치 조회
+		character := characterOrDefault(client.character)
+		maxHealth := max(1, g.gameplay.MaxHealth+character.Stats.HealthBonus)
 
 		g.players[client] = &PlayerState{
 			Color:    client.color,
@@ -132,20 +205,29 @@ func NewGame(room *Room, gamePlayers []*Client) *Game {
 			Yaw:              -angle + math.Pi, // 중심 바라보도록
 			Pitch:            0,
 			Score:            0,
-			Asset:            assetFile,
-			Health:           maxPlayerHealth,
-			MaxHealth:        maxPlayerHealth,
+			Asset:            character.Asset,
+			Team:             client.team,
+			Character:        character.ID,
+			Stats:            character.Stats,
+			Ability:          character.Ability.ID,
+			Health:           maxHealth,
+			MaxHealth:        maxHealth,
 			IsAlive:          true,
 			IsInvincible:     false,
 			IsConnected:      true,
+			IsBot:            client.isBot,
 			MoveForward:      0,
 			MoveStrafe:       0,
 			CurrentAnimation: "idle", // 기본 애니메이션
 			AnimationStart:   time.Now(),
+			antiCheat:        newAntiCheatState(-angle+math.Pi, time.Now()),
+		}
+
+		if client.isBot {
+			g.bots[client] = newBotBrain(client.botDifficulty)
 		}
 
-		log.Printf("Player %s (Nick: %s) spawned at position (%.2f, 0, %.2f) with yaw %.2f",
-			client.id, client.nickname, x, z, g.players[client].Yaw)
+		g.logger.Debug("Player spawned", "client_id", client.id, "x", x, "z", z, "yaw", g.players[client].Yaw)
 	}
 	return g
 }
@@ -154,7 +236,7 @@ func NewGame(room *Room, gamePlayers []*Client) *Game {
 // 카운트 다운 시작 전
 // yaw 업데이트 가능, 위치 업데이트 불가능
 func (g *Game) Ready() {
-	log.Printf("Game in Room %s: Ready...", g.room.id)
+	g.logger.Debug("Game ready")
 	g.isReady = true
 
 	g.room.mutex.Lock()
@@ -167,23 +249,32 @@ func (g *Game) Ready() {
 
 // 카운트다운 후 게임 루프 실행
 func (g *Game) Start() {
-	log.Printf("Game in Room %s: Starting countdown...", g.room.id)
+	g.logger.Debug("Starting countdown")
 
-	for i := countdownSeconds; i > 0; i-- {
+	for i := g.gameplay.CountdownSeconds; i > 0; i-- {
 		countdownPayload := GameCountdownPayload{SecondsLeft: i}
 		msg := Message{Type: MessageTypeGameCountdown, Payload: countdownPayload}
 		g.room.broadcastMessage(msg, nil)
 		time.Sleep(time.Second)
 	}
 
+	// 게임 루프, 관리자 API가 읽으므로 Lock 상태에서 변경
+	// 생존 시간은 카운트 다운 이후부터 측정
+	g.mutex.Lock()
 	g.startTime = time.Now()
 	g.isRunning = true
+	for _, ps := range g.players {
+		if ps.IsConnected && ps.IsAlive {
+			ps.Combat.markAlive(g.startTime)
+		}
+	}
+	g.mutex.Unlock()
 
 	startMsg := Message{Type: MessageTypeGameStarted, Payload: nil}
 	g.room.broadcastMessage(startMsg, nil)
 	g.room.server.broadcastRoomUpdate()
 
-	log.Printf("Game in Room %s: Started! Duration: %v", g.room.id, g.duration)
+	g.logger.Info("Game started", "duration", g.duration)
 }
 
 // 메인 루프
@@ -192,27 +283,39 @@ func (g *Game) gameLoop() {
 		if g.ticker != nil {
 			g.ticker.Stop()
 		}
+		g.mutex.Lock()
 		g.isReady = false
-		log.Printf("Game in Room %s: Gameloop stopped.", g.room.id)
+		g.mutex.Unlock()
+		g.logger.Debug("Game loop stopped")
 	}()
 
 	for {
 		select {
 		case <-g.ticker.C:
-			if !g.isReady {
+			g.mutex.RLock()
+			ready := g.isReady
+			g.mutex.RUnlock()
+			if !ready {
 				return
 			}
+			tickStart := time.Now()
 			g.updateGameState()
+			g.broadcastBalanceUpdate()
+			g.broadcastEvents()
 			g.broadcastGameState()
+			metrics.observeTick(time.Since(tickStart))
 
-			if g.isRunning && time.Since(g.startTime) >= g.duration {
-				log.Printf("Game in Room %s: Time is up!", g.room.id)
+			g.mutex.RLock()
+			timeUp := g.isRunning && time.Since(g.startTime) >= g.duration
+			g.mutex.RUnlock()
+			if timeUp {
+				g.logger.Debug("Time is up")
 				g.StopGame("time_up")
 				return
 			}
 
 		case <-g.quit:
-			log.Printf("Game in Room %s: Quit signal received, stopping gameloop.", g.room.id)
+			g.logger.Debug("Quit signal received, stopping game loop")
 			return
 		}
 	}
@@ -223,6 +326,14 @@ func (g *Game) updateGameState() {
 	// Game Mutex 전체 Lock
 	g.mutex.Lock()
 
+	g.tick++
+
+	// 리로드된 밸런스 적용
+	g.applyPendingBalance()
+
+	// 봇 입력 결정
+	g.updateBots()
+
 	// 플레이어 업데이트
 	for _, ps := range g.players {
 		if !ps.IsConnected {
@@ -231,13 +342,13 @@ func (g *Game) updateGameState() {
 		}
 
 		// 부활 처리
-		if !ps.IsAlive && time.Since(ps.DeathTime) >= respawnDelay {
+		if !ps.IsAlive && time.Since(ps.DeathTime) >= time.Duration(g.gameplay.RespawnDelay) {
 			ps.Health = ps.MaxHealth
 			ps.IsAlive = true
 			ps.RespawnTime = time.Now()
 			// 부활 무적
 			ps.IsInvincible = true
-			ps.InvincibleUntil = time.Now().Add(invincibleDuration)
+			ps.InvincibleUntil = time.Now().Add(time.Duration(g.balance.InvincibleDuration))
 			// 리스폰 시 원점으로 이동
 			ps.X = 0
 			ps.Y = 0
@@ -245,20 +356,39 @@ func (g *Game) updateGameState() {
 			// 부활 애니메이션 설정
 			ps.CurrentAnimation = "respawn"
 			ps.AnimationStart = time.Now()
-			log.Printf("Player %s respawned with full health at origin (0,0,0)", ps.ID)
+			if g.isRunning {
+				ps.Combat.markAlive(ps.RespawnTime)
+			}
+			g.emitEvent(GameEvent{Type: GameEventRespawn, PlayerID: ps.ID}, ps.RespawnTime)
+			g.logger.Debug("Player respawned", "client_id", ps.ID)
 		}
 
+		// 충전 시간 초과 처리
+		g.updateCharge(ps)
+
 		// 애니메이션 자동 종료
-		if ps.CurrentAnimation != "" && ps.CurrentAnimation != "idle" && ps.CurrentAnimation != "walk_forward" {
+		// 충전, 막기 애니메이션은 해제될 때까지 유지
+		if ps.CurrentAnimation != "" && ps.CurrentAnimation != "idle" && ps.CurrentAnimation != "walk_forward" &&
+			ps.CurrentAnimation != chargeAnimation && ps.CurrentAnimation != blockAnimation {
 			animationDuration := 2 * time.Second
 			if ps.CurrentAnimation == "hammer_attack" {
 				animationDuration = hammerDuration
+			} else if ps.CurrentAnimation == heavyAttackAnimation {
+				animationDuration = ps.RecoverUntil.Sub(ps.AnimationStart)
 			} else if ps.CurrentAnimation == "death" {
 				animationDuration = deathDuration
 			} else if ps.CurrentAnimation == "respawn" {
 				animationDuration = respawnDuration
 			} else if ps.CurrentAnimation == "hit" {
 				animationDuration = hitDuration
+			} else if ps.CurrentAnimation == dashAnimation {
+				animationDuration = dashDuration
+			} else if ps.CurrentAnimation == throwAnimation {
+				animationDuration = throwDuration
+			} else if isEmoteAnimation(ps.CurrentAnimation) {
+				animationDuration = emoteDuration
+			} else if isAbilityAnimation(ps.CurrentAnimation) {
+				animationDuration = abilityAnimationDuration(ps.CurrentAnimation)
 			}
 
 			if time.Since(ps.AnimationStart) >= animationDuration {
@@ -284,13 +414,30 @@ func (g *Game) updateGameState() {
 			continue
 		}
 
+		// 공격 중 받은 회전은 공격이 끝난 뒤 반영
+		if !ps.IsBot && ps.Yaw != ps.antiCheat.lookYaw && !ps.isAttacking() {
+			ps.Yaw = ps.antiCheat.lookYaw
+		}
+
 		// 공격 중에는 이동 Skip
-		if time.Since(ps.LastAttackTime) < hammerDuration {
+		if ps.isAttacking() {
 			continue
 		}
 
 		// 피격시 이동 Skip
-		if time.Since(ps.LastHitTime) < hitDuration {
+		if ps.isStunned() {
+			continue
+		}
+
+		// 대시 중에는 입력 대신 대시 이동 처리
+		if ps.isDashing() {
+			g.updateDash(ps)
+			continue
+		}
+
+		// 능력 사용 중에는 입력 대신 능력 이동 처리
+		if ps.isUsingAbility() {
+			g.updateAbilityMovement(ps)
 			continue
 		}
 
@@ -310,8 +457,9 @@ func (g *Game) updateGameState() {
 		if deltaX != 0 || deltaZ != 0 {
 			magnitude := math.Sqrt(deltaX*deltaX + deltaZ*deltaZ)
 			if magnitude > 0 {
-				deltaX = (deltaX / magnitude) * playerSpeed
-				deltaZ = (deltaZ / magnitude) * playerSpeed
+				speed := g.playerSpeed(ps)
+				deltaX = (deltaX / magnitude) * speed
+				deltaZ = (deltaZ / magnitude) * speed
 			}
 		}
 
@@ -343,6 +491,15 @@ func (g *Game) updateGameState() {
 	// 공격 처리
 	g.updateHammerAttacks()
 
+	// 투사체, 지속 범위 효과 처리
+	g.updateAttackEntities()
+
+	// 경기장 축소, 낙하물 처리
+	g.updateArena()
+
+	// 만료된 핑 제거
+	g.updatePings()
+
 	// Game Mutex 전체 Unlock
 	g.mutex.Unlock()
 }
@@ -358,50 +515,47 @@ func (g *Game) updateHammerAttacks() {
 			continue
 		}
 
+		// 공격자 상태 조회
+		var attackerPs *PlayerState
+		for _, ps := range g.players {
+			if ps.ID == attack.AttackerID {
+				attackerPs = ps
+				break
+			}
+		}
+
 		// 공격 범위 내 플레이어들 확인
 		hitPlayerIDs := g.checkHammerPlayerCollision(attack)
 
+		damage := damageInfo{
+			amount:      attack.Damage,
+			sourceX:     attack.X,
+			sourceZ:     attack.Z,
+			breaksBlock: attack.Heavy,
+			source:      damageSourceHammer,
+		}
+		if attack.Heavy {
+			damage.source = damageSourceHeavy
+		}
+		hit := false
 		for _, hitPlayerID := range hitPlayerIDs {
-			if hitPlayerID != attack.AttackerID {
-				// 피해 처리
-				for _, ps := range g.players {
-					if ps.ID == hitPlayerID && ps.IsAlive {
-						ps.Health -= hammerDamage
-						log.Printf("Player %s hit player %s with hammer! Damage: %d, Remaining health: %d",
-							attack.AttackerID, hitPlayerID, hammerDamage, ps.Health)
-
-						// 죽음 처리
-						if ps.Health <= 0 {
-							ps.Health = 0
-							ps.IsAlive = false
-							ps.LastHitTime = now
-							ps.DeathTime = now
-							ps.CurrentAnimation = "death"
-							ps.AnimationStart = now
-							log.Printf("Player %s was killed by %s!", hitPlayerID, attack.AttackerID)
-
-							// 킬한 플레이어에게만 점수 추가
-							for _, attackerPs := range g.players {
-								if attackerPs.ID == attack.AttackerID {
-									attackerPs.Score++
-									log.Printf("Player %s got a KILL! Score: %d", attack.AttackerID, attackerPs.Score)
-									break
-								}
-							}
-						} else {
-							// 맞기
-							ps.CurrentAnimation = "hit"
-							ps.AnimationStart = now
-							ps.LastHitTime = now
-							// 일시 무적처리
-							ps.InvincibleUntil = now.Add(invincibleDuration)
-							ps.IsInvincible = true
-						}
-						break
+			if hitPlayerID == attack.AttackerID {
+				continue
+			}
+			// 피해 처리
+			for _, ps := range g.players {
+				if ps.ID == hitPlayerID {
+					if g.damagePlayer(attackerPs, ps, damage, now) {
+						hit = true
 					}
+					break
 				}
 			}
 		}
+		// 명중률 기록
+		if hit && attackerPs != nil {
+			attackerPs.Combat.Hits++
+		}
 
 		// 공격 판정 후 제거
 		toDelete = append(toDelete, attackID)
@@ -413,13 +567,99 @@ func (g *Game) updateHammerAttacks() {
 	}
 }
 
+// 피해 처리
+// 망치, 능력 공통, 실제로 피해를 입혔으면 true
+// g.mutex Lock 상태에서 호출
+func (g *Game) damagePlayer(attacker, victim *PlayerState, damage damageInfo, now time.Time) bool {
+	// 연결 끊긴 플레이어, 죽은 플레이어, 무적 상태 플레이어는 Skip (환경 피해는 무적 무시)
+	if attacker == victim || !victim.IsConnected || !victim.IsAlive || (victim.IsInvincible && !damage.environmental) {
+		return false
+	}
+
+	// 아군 공격 비허용 시 같은 팀 Skip
+	friendly := attacker != nil && g.isTeammate(attacker, victim)
+	if friendly && !g.settings.FriendlyFire {
+		return false
+	}
+
+	attackerID := ""
+	if attacker != nil {
+		attackerID = attacker.ID
+	}
+
+	// 정면 막기
+	if g.tryBlock(victim, damage, now) {
+		g.logger.Debug("Hit blocked", "attacker_id", attackerID, "victim_id", victim.ID)
+		return false
+	}
+
+	// 맞으면 충전, 막기 해제
+	victim.IsCharging = false
+	victim.IsBlocking = false
+
+	// 실제로 깎인 체력만 기록
+	dealt := min(damage.amount, victim.Health)
+	victim.Combat.DamageTaken += dealt
+	if attacker != nil && !friendly {
+		attacker.Combat.DamageDealt += dealt
+	}
+
+	victim.Health -= damage.amount
+	g.logger.Debug("Player hit", "attacker_id", attackerID, "victim_id", victim.ID, "damage", damage.amount, "health", victim.Health)
+
+	// 죽음 처리
+	if victim.Health <= 0 {
+		victim.Health = 0
+		victim.IsAlive = false
+		victim.LastHitTime = now
+		victim.DeathTime = now
+		victim.CurrentAnimation = "death"
+		victim.AnimationStart = now
+		victim.stopDash()
+		victim.Combat.addDeath(now)
+		g.logger.Debug("Player killed", "attacker_id", attackerID, "victim_id", victim.ID)
+
+		// 킬한 플레이어에게만 점수 추가, 방 점수 규칙에 따라 추가 점수, 감점
+		// 아군 처치는 점수 없음
+		score := g.scoreKill(attacker, victim, friendly, now)
+		if score.points > 0 {
+			g.logger.Debug("Kill scored", "client_id", attackerID, "points", score.points, "bonuses", score.bonuses, "score", attacker.Score)
+		}
+		g.emitEvent(GameEvent{
+			Type:       GameEventKill,
+			AttackerID: attackerID,
+			VictimID:   victim.ID,
+			Source:     damage.source,
+			Damage:     dealt,
+			Points:     score.points,
+			Bonuses:    score.bonuses,
+			PointsLost: score.pointsLost,
+		}, now)
+		if attacker != nil && !friendly && attacker.Combat.currentStreak >= streakEventMin {
+			g.emitEvent(GameEvent{Type: GameEventStreak, PlayerID: attackerID, Streak: attacker.Combat.currentStreak}, now)
+		}
+	} else {
+		// 맞기
+		victim.CurrentAnimation = "hit"
+		victim.AnimationStart = now
+		victim.LastHitTime = now
+		// 일시 무적처리 (환경 피해는 제외)
+		if !damage.environmental {
+			victim.InvincibleUntil = now.Add(time.Duration(g.balance.InvincibleDuration))
+			victim.IsInvincible = true
+		}
+		g.emitEvent(GameEvent{Type: GameEventHit, AttackerID: attackerID, VictimID: victim.ID, Source: damage.source, Damage: dealt}, now)
+	}
+	return true
+}
+
 // 공격 충돌 확인
 func (g *Game) checkHammerPlayerCollision(attack *HammerAttack) []string {
 	hitPlayers := make([]string, 0)
 
 	// 공격 위치
-	attackX := attack.X + attack.DirectionX*hammerRange
-	attackZ := attack.Z + attack.DirectionZ*hammerRange
+	attackX := attack.X + attack.DirectionX*attack.Range
+	attackZ := attack.Z + attack.DirectionZ*attack.Range
 
 	for _, ps := range g.players {
 		// 연결 끊긴 플레이어, 죽은 플레이어, 무적 상태 플레이어는 Skip
@@ -433,7 +673,7 @@ func (g *Game) checkHammerPlayerCollision(attack *HammerAttack) []string {
 		distance := math.Sqrt(dx*dx + dz*dz)
 
 		// 범위 내에 있는지 확인
-		if distance <= hammerRange {
+		if distance <= attack.Range {
 			hitPlayers = append(hitPlayers, ps.ID)
 		}
 	}
@@ -461,11 +701,20 @@ func (g *Game) broadcastGameState() {
 			Pitch:            ps.Pitch,
 			Score:            ps.Score,
 			Asset:            ps.Asset,
+			Team:             ps.Team,
+			Character:        ps.Character,
 			Health:           ps.Health,
 			MaxHealth:        ps.MaxHealth,
 			IsAlive:          ps.IsAlive,
 			IsInvincible:     ps.IsInvincible,
+			IsSlowed:         time.Now().Before(ps.SlowedUntil),
+			IsBlocking:       ps.IsBlocking,
+			ChargeLevel:      ps.chargeLevel(),
+			IsBot:            ps.IsBot,
 			CurrentAnimation: ps.CurrentAnimation,
+			AbilityCooldown:  ps.abilityCooldownLeft().Milliseconds(),
+			DashCooldown:     ps.dashCooldownLeft().Milliseconds(),
+			ThrowCooldown:    ps.throwCooldownLeft().Milliseconds(),
 		})
 	}
 
@@ -478,18 +727,40 @@ func (g *Game) broadcastGameState() {
 	}
 
 	gameStatePayload := GameStateUpdatePayload{
-		Players:  playerStatesInfo,
-		TimeLeft: timeLeft,
+		Players:    playerStatesInfo,
+		TimeLeft:   timeLeft,
+		Tick:       g.tick,
+		ServerTime: time.Now().UnixMilli(),
+		TeamScores: g.teamScores(),
+		GameState: GameSpecificState{
+			Pings:          g.activePings(),
+			AttackEntities: g.activeAttackEntities(),
+			Arena:          g.arena,
+			Hazards:        g.activeHazards(),
+		},
 	}
 
 	msg := Message{Type: MessageTypeGameStateUpdate, Payload: gameStatePayload}
-	g.room.broadcastMessage(msg, nil)
+	g.broadcastAndRecord(msg)
+}
+
+// 방 전체 Broadcast 및 리플레이 기록
+// 한 번만 Marshal해서 전송과 기록에 같이 사용
+func (g *Game) broadcastAndRecord(msg Message) {
+	payloadBytes, err := json.Marshal(msg)
+	if err != nil {
+		metrics.marshalErrors.Add(1)
+		g.logger.Error("Failed to marshal message", "type", msg.Type, "error", err)
+		return
+	}
+	g.room.broadcastToClients(payloadBytes)
+	g.recorder.record(payloadBytes)
 }
 
 // 플레이어 액션 처리
 func (g *Game) HandlePlayerAction(msg *Message) {
 	if !g.isReady {
-		log.Printf("Game in Room %s: Received player action for client %s (Nick: %s) but game is not running. Ignored.", g.room.id, msg.Sender.id, msg.Sender.nickname)
+		g.logger.Debug("Player action ignored, game is not running", "client_id", msg.Sender.id)
 		return
 	}
 
@@ -499,7 +770,7 @@ func (g *Game) HandlePlayerAction(msg *Message) {
 	client := msg.Sender
 	playerState, ok := g.players[client]
 	if !ok || !playerState.IsConnected {
-		log.Printf("Game in Room %s: Player action from unknown or disconnected client %s (Nick: %s). Ignored.", g.room.id, client.id, client.nickname)
+		g.logger.Debug("Player action from unknown or disconnected client ignored", "client_id", client.id)
 		return
 	}
 
@@ -510,26 +781,29 @@ func (g *Game) HandlePlayerAction(msg *Message) {
 
 	actionPayloadMap, ok := msg.Payload.(map[string]interface{})
 	if !ok {
-		log.Printf("Game in Room %s: Could not parse PlayerActionPayload (not a map) for client %s", g.room.id, client.id)
+		g.logger.Warn("Invalid player action payload", "client_id", client.id)
 		return
 	}
 
 	actionType, _ := actionPayloadMap["action_type"].(string)
 	actionData, dataOk := actionPayloadMap["data"].(map[string]interface{})
 	if !dataOk {
-		log.Printf("Game in Room %s: Could not parse PlayerActionPayload data (not a map) for client %s, type %s", g.room.id, client.id, actionType)
+		g.logger.Warn("Invalid player action data", "client_id", client.id, "action", actionType)
 		return
 	}
 
 	switch actionType {
 	case "look":
-		// 공격 중에는 회전 Skip
-		if time.Since(playerState.LastAttackTime) < 500*time.Millisecond {
-			return
-		}
-
+		// 회전 속도 제한 적용
+		// 공격 중에는 기록만 하고 공격이 끝난 뒤 반영
 		if yawVal, ok := actionData["yaw"].(float64); ok {
-			playerState.Yaw = yawVal
+			yaw := g.limitYawChange(playerState, yawVal)
+			if !playerState.isAttacking() {
+				playerState.Yaw = yaw
+			}
+		}
+		if playerState.isAttacking() {
+			return
 		}
 		if pitchVal, ok := actionData["pitch"].(float64); ok {
 			// Pitch 값 서버 제한
@@ -543,12 +817,12 @@ func (g *Game) HandlePlayerAction(msg *Message) {
 		}
 
 		// 공격 중 이동 Skip
-		if time.Since(playerState.LastAttackTime) < hammerDuration {
+		if playerState.isAttacking() {
 			return
 		}
 
 		// 피격 중 이동 Skip
-		if time.Since(playerState.LastHitTime) < hitDuration {
+		if playerState.isStunned() {
 			return
 		}
 
@@ -569,63 +843,118 @@ func (g *Game) HandlePlayerAction(msg *Message) {
 
 	case "click":
 		// 공격 쿨타임 체크
-		if time.Since(playerState.LastAttackTime) < hammerDuration {
+		// 충전 공격 후딜 중에는 쿨타임이 지나도 Skip
+		if time.Since(playerState.LastAttackTime) < g.hammerCooldown(playerState) || time.Now().Before(playerState.RecoverUntil) {
 			return
 		}
 
 		// 피격시 Skip
-		if time.Since(playerState.LastHitTime) < hitDuration {
+		if playerState.isStunned() {
+			return
+		}
+
+		// 능력 사용, 대시, 충전, 막기 중 Skip
+		if playerState.isUsingAbility() || playerState.isDashing() || playerState.IsCharging || playerState.IsBlocking {
 			return
 		}
 
 		if directionData, ok := actionData["direction"].(map[string]interface{}); ok {
 			if dirX, okX := directionData["x"].(float64); okX {
 				if dirZ, okZ := directionData["z"].(float64); okZ {
-
-					// 게임중일때만 실제 공격 생성
-					// 카운트 다운 이전 공격은 애니메이션은 취하되 실제 공격 로직은 무시
-					if g.isRunning {
-						// 공격 생성
-						g.attackCounter++
-						attackID := fmt.Sprintf("hammer_%s_%d", client.id, g.attackCounter)
-
-						attack := &HammerAttack{
-							ID:         attackID,
-							AttackerID: client.id,
-							X:          playerState.X,
-							Z:          playerState.Z,
-							DirectionX: dirX,
-							DirectionZ: dirZ,
-							CreatedAt:  time.Now(),
-							HitTime:    time.Now().Add(50 * time.Millisecond), // 0.05초 후 타격 판정
-							Color:      client.color,
-						}
-
-						g.hammerAttacks[attackID] = attack
-
-						log.Printf("Player %s performed hammer attack %s at direction (%.2f, %.2f)", client.id, attackID, dirX, dirZ)
+					// 방향 정규화, 바라보는 방향과 비교
+					if dirX, dirZ, ok := g.validateAttackDirection(playerState, dirX, dirZ, actionData); ok {
+						g.performHammerAttack(playerState, dirX, dirZ, 0)
 					}
+				}
+			}
+		}
 
-					// 공격 시간 기록
-					playerState.LastAttackTime = time.Now()
+	case "emote":
+		g.handleEmoteAction(client, playerState, actionData)
 
-					// 공격 중에는 이동 중지
-					playerState.MoveForward = 0
-					playerState.MoveStrafe = 0
+	case "ping":
+		g.handlePingAction(client, playerState, actionData)
 
-					playerState.CurrentAnimation = "hammer_attack"
-					playerState.AnimationStart = time.Now()
+	case "ability":
+		g.handleAbilityAction(client, playerState)
 
-				}
-			}
-		}
+	case "dash":
+		g.handleDashAction(client, playerState)
+
+	case "throw":
+		g.handleThrowAction(client, playerState, actionData)
+
+	case "charge_start":
+		g.handleChargeStart(client, playerState)
+
+	case "charge_release":
+		g.handleChargeRelease(client, playerState, actionData)
+
+	case "block":
+		g.handleBlockAction(client, playerState, actionData)
 
 	default:
-		log.Printf("Game in Room %s: Unknown player action type '%s' from %s", g.room.id, actionType, client.id)
+		g.logger.Warn("Unknown player action type", "client_id", client.id, "action", actionType)
 	}
 	playerState.LastActionTime = time.Now()
 }
 
+// 망치 공격 수행
+// chargeLevel이 heavyMinCharge 이상이면 충전 공격 (데미지, 범위 증가, 후딜 추가, 막기 파괴)
+// 플레이어 click, charge_release 액션과 봇이 공통으로 사용
+// g.mutex Lock 상태에서 호출
+func (g *Game) performHammerAttack(ps *PlayerState, dirX, dirZ, chargeLevel float64) {
+	now := time.Now()
+	heavy := chargeLevel >= heavyMinCharge
+	damage := g.balance.HammerDamage
+	attackRange := g.hammerRange(ps)
+	animation := "hammer_attack"
+	if heavy {
+		damage += int(math.Round(chargeLevel * heavyMaxBonusDamage))
+		attackRange *= 1 + heavyRangeBonus*chargeLevel
+		ps.RecoverUntil = now.Add(hammerDuration + time.Duration(chargeLevel*float64(heavyMaxRecovery)))
+		animation = heavyAttackAnimation
+	}
+
+	// 게임중일때만 실제 공격 생성
+	// 카운트 다운 이전 공격은 애니메이션은 취하되 실제 공격 로직은 무시
+	if g.isRunning {
+		// 공격 생성
+		g.attackCounter++
+		attackID := fmt.Sprintf("hammer_%s_%d", ps.ID, g.attackCounter)
+
+		attack := &HammerAttack{
+			ID:         attackID,
+			AttackerID: ps.ID,
+			X:          ps.X,
+			Z:          ps.Z,
+			DirectionX: dirX,
+			DirectionZ: dirZ,
+			CreatedAt:  now,
+			HitTime:    now.Add(50 * time.Millisecond), // 0.05초 후 타격 판정
+			Range:      attackRange,
+			Damage:     damage,
+			Heavy:      heavy,
+			Color:      ps.Color,
+		}
+
+		g.hammerAttacks[attackID] = attack
+		ps.Combat.Swings++
+
+		g.logger.Debug("Hammer attack", "client_id", ps.ID, "attack_id", attackID, "dir_x", dirX, "dir_z", dirZ, "charge", chargeLevel)
+	}
+
+	// 공격 시간 기록
+	ps.LastAttackTime = now
+
+	// 공격 중에는 이동 중지
+	ps.MoveForward = 0
+	ps.MoveStrafe = 0
+
+	ps.CurrentAnimation = animation
+	ps.AnimationStart = now
+}
+
 // 게임 중지
 func (g *Game) StopGame(reason string) {
 	g.mutex.Lock()
@@ -635,8 +964,11 @@ func (g *Game) StopGame(reason string) {
 	}
 	g.isReady = false
 
-	// 공격 모두 제거
+	// 공격, 핑, 낙하물 모두 제거
 	g.hammerAttacks = make(map[string]*HammerAttack)
+	g.attackEntities = make(map[string]*AttackEntity)
+	g.pings = make(map[string]*PingMarker)
+	g.hazards = make(map[string]*Hazard)
 
 	// g.quit 채널을 닫아서 gameLoop 종료 신호
 	if g.quit != nil {
@@ -648,26 +980,42 @@ func (g *Game) StopGame(reason string) {
 	}
 	g.mutex.Unlock()
 
-	log.Printf("Game in Room %s: Stopping game. Reason: %s", g.room.id, reason)
+	g.logger.Info("Stopping game", "reason", reason)
+	metrics.gameEnds.Inc(reason)
 
 	// 게임 종료 Msg
 	finalScores := make([]PlayerScore, 0, len(g.players))
-	g.mutex.RLock()
+	now := time.Now()
+	g.mutex.Lock()
 	for client, ps := range g.players {
+		ps.Combat.finish(now)
+		stats := ps.Combat
 		finalScores = append(finalScores, PlayerScore{
 			PlayerID: client.id,
 			Nickname: client.nickname,
 			Score:    ps.Score,
+			Team:     ps.Team,
+			Stats:    &stats,
 		})
 	}
-	g.mutex.RUnlock()
+	teamScores := g.teamScores()
+	g.mutex.Unlock()
 
 	gameEndedPayload := GameEndedPayload{
 		FinalScores: finalScores,
 		Reason:      reason,
+		TeamScores:  teamScores,
+		Awards:      computeAwards(finalScores),
+		Scoring:     g.settings.Scoring,
+	}
+	if teamScores != nil {
+		gameEndedPayload.WinningTeam = winningTeam(teamScores)
 	}
 	msg := Message{Type: MessageTypeGameEnded, Payload: gameEndedPayload}
-	g.room.broadcastMessage(msg, nil)
+	g.broadcastAndRecord(msg)
+
+	// 리플레이 저장
+	g.recorder.close(reason, finalScores)
 
 	// 게임 종료 및 준비상태 초기화
 	g.room.SetGameEnded()
@@ -676,7 +1024,7 @@ func (g *Game) StopGame(reason string) {
 	go func(room *Room) {
 		if room != nil {
 			time.Sleep(gameEndDelay)
-			log.Printf("Game in Room %s: Delay finished. Preparing room for new game.", room.id)
+			room.logger.Debug("Game end delay finished, preparing room for new game")
 			room.PrepareForNewGame()
 		}
 	}(g.room)
@@ -696,9 +1044,14 @@ func (g *Game) UpdatePlayerConnectionState(client *Client, isConnected bool) {
 			// 이동 초기화
 			ps.MoveForward = 0
 			ps.MoveStrafe = 0
-			log.Printf("Game in Room %s: Player %s (Nick: %s) connection state updated to DISCONNECTED. Movement reset.", g.room.id, client.id, client.nickname)
+			ps.Combat.markDead(time.Now())
+			g.emitEvent(GameEvent{Type: GameEventDisconnect, PlayerID: ps.ID}, time.Now())
+			g.logger.Info("Player disconnected during game, movement reset", "client_id", client.id)
 		} else {
-			log.Printf("Game in Room %s: Player %s (Nick: %s) connection state updated to CONNECTED.", g.room.id, client.id, client.nickname)
+			if g.isRunning && ps.IsAlive {
+				ps.Combat.markAlive(time.Now())
+			}
+			g.logger.Info("Player reconnected during game", "client_id", client.id)
 		}
 	}
 }
@@ -0,0 +1,61 @@
+package backend
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// 플레이어 액션처럼 매 틱 발생하는 로그의 샘플링 비율
+const hotPathLogSampleRate = 100
+
+// 로그 레벨 문자열 파싱 (debug, info, warn, error)
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("unknown log level %q", level)
+}
+
+// 서버 로거 생성
+// jsonFormat이 false면 key=value 텍스트 형식
+func NewLogger(w io.Writer, level slog.Level, jsonFormat bool) *slog.Logger {
+	options := &slog.HandlerOptions{Level: level}
+	if jsonFormat {
+		return slog.New(slog.NewJSONHandler(w, options))
+	}
+	return slog.New(slog.NewTextHandler(w, options))
+}
+
+// 샘플링 대상 로그
+var (
+	playerActionLogSampler = newLogSampler(hotPathLogSampleRate)
+	droppedSendLogSampler  = newLogSampler(hotPathLogSampleRate)
+)
+
+// 빈번한 로그 샘플링
+// every번 호출 중 첫 번째 한 번만 true
+type logSampler struct {
+	every uint64
+	count atomic.Uint64
+}
+
+func newLogSampler(every uint64) *logSampler {
+	if every == 0 {
+		every = 1
+	}
+	return &logSampler{every: every}
+}
+
+func (s *logSampler) Sample() bool {
+	return s.count.Add(1)%s.every == 1 || s.every == 1
+}
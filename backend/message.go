@@ -7,6 +7,7 @@ type MessageType string
 const (
 	// From Client To Server
 	MessageTypeSetNicknameColor    MessageType = "set_nickname_color"
+	MessageTypeProfileUpdated      MessageType = "profile_updated"
 	MessageTypeCreateRoom          MessageType = "create_room"
 	MessageTypeJoinRoom            MessageType = "join_room"
 	MessageTypeListRooms           MessageType = "list_rooms"
@@ -15,6 +16,13 @@ const (
 	MessageTypeStartGame           MessageType = "start_game"
 	MessageTypePlayerAction        MessageType = "player_action"
 	MessageTypeGameLoadingComplete MessageType = "game_loading_complete"
+	MessageTypeUpdateRoomSettings  MessageType = "update_room_settings"
+	MessageTypeSetTeam             MessageType = "set_team"
+	MessageTypeAutoBalanceTeams    MessageType = "auto_balance_teams"
+	MessageTypeAddBot              MessageType = "add_bot"
+	MessageTypeRemoveBot           MessageType = "remove_bot"
+	MessageTypeWatchReplay         MessageType = "watch_replay"
+	MessageTypeReplayControl       MessageType = "replay_control"
 
 	// From Server To Client
 	MessageTypeError              MessageType = "error"
@@ -30,7 +38,14 @@ const (
 	MessageTypeGameStarted        MessageType = "game_started"
 	MessageTypeGameStateUpdate    MessageType = "game_state_update"
 	MessageTypeGameEnded          MessageType = "game_ended"
+	MessageTypeGameEvent          MessageType = "game_event"
 	MessageTypeRoomStateUpdated   MessageType = "room_state_updated"
+	MessageTypeReplayStatus       MessageType = "replay_status"
+	MessageTypeRoomRedirect       MessageType = "room_redirect"
+	MessageTypeServerShuttingDown MessageType = "server_shutting_down"
+	MessageTypeRoomClosed         MessageType = "room_closed"
+	MessageTypeAnnouncement       MessageType = "announcement"
+	MessageTypeBalanceUpdated     MessageType = "balance_updated"
 )
 
 // 기본 Message 타입
@@ -58,6 +73,31 @@ type JoinRoomPayload struct {
 	RoomID string `json:"room_id"`
 }
 
+// 방 설정 변경
+// nil인 항목은 변경하지 않음
+type UpdateRoomSettingsPayload struct {
+	TeamMode       *bool                `json:"team_mode,omitempty"`
+	FriendlyFire   *bool                `json:"friendly_fire,omitempty"`
+	ShrinkingArena *bool                `json:"shrinking_arena,omitempty"`
+	Hazards        *bool                `json:"hazards,omitempty"`
+	Scoring        *ScoringRulesPayload `json:"scoring,omitempty"`
+}
+
+// 팀 선택
+type SetTeamPayload struct {
+	Team string `json:"team"`
+}
+
+// 봇 추가
+type AddBotPayload struct {
+	Difficulty BotDifficulty `json:"difficulty"`
+}
+
+// 봇 제거
+type RemoveBotPayload struct {
+	BotID string `json:"bot_id"`
+}
+
 // 플레이어 액션
 type PlayerActionPayload struct {
 	ActionType string      `json:"action_type"`
@@ -66,12 +106,22 @@ type PlayerActionPayload struct {
 
 // 에러 Payload
 type ErrorPayload struct {
-	Message string `json:"message"`
+	Code    ErrorCode `json:"code,omitempty"`
+	Message string    `json:"message"`
 }
 
 // 유저 ID 할당
 type UserIDAssignedPayload struct {
-	UserID string `json:"user_id"`
+	UserID     string          `json:"user_id"`
+	Characters []CharacterInfo `json:"characters"` // 선택 가능한 캐릭터 목록
+}
+
+// 프로필 설정 완료
+// 서버에서 정리한 값 (앞뒤 공백 제거 등)
+type ProfileUpdatedPayload struct {
+	Nickname  string `json:"nickname"`
+	Color     string `json:"color"`
+	Character string `json:"character"`
 }
 
 // 방 정보
@@ -82,17 +132,21 @@ type RoomInfo struct {
 	MaxPlayers     int          `json:"max_players"`
 	State          RoomState    `json:"state"`
 	CurrentPlayers int          `json:"current_players"`
+	Settings       RoomSettings `json:"settings"`
 }
 
 // 대기실에서 플레이어 상태
 type PlayerInfo struct {
-	ID        string `json:"id"`
-	Nickname  string `json:"nickname"`
-	Color     string `json:"color"`
-	Character string `json:"character,omitempty"`
-	Asset     string `json:"asset,omitempty"`
-	IsReady   bool   `json:"is_ready"`
-	IsOwner   bool   `json:"is_owner"`
+	ID            string        `json:"id"`
+	Nickname      string        `json:"nickname"`
+	Color         string        `json:"color"`
+	Character     string        `json:"character,omitempty"`
+	Asset         string        `json:"asset,omitempty"`
+	Team          string        `json:"team,omitempty"`
+	IsReady       bool          `json:"is_ready"`
+	IsOwner       bool          `json:"is_owner"`
+	IsBot         bool          `json:"is_bot,omitempty"`
+	BotDifficulty BotDifficulty `json:"bot_difficulty,omitempty"`
 }
 
 // 방 리스트
@@ -106,6 +160,7 @@ type RoomListItem struct {
 	CurrentPlayers int       `json:"current_players"`
 	MaxPlayers     int       `json:"max_players"`
 	State          RoomState `json:"state"`
+	NodeID         string    `json:"node_id,omitempty"` // 멀티 노드 환경에서 방을 소유한 노드
 }
 
 // 게임 시작 카운트다운
@@ -115,9 +170,20 @@ type GameCountdownPayload struct {
 
 // 게임 상태 업데이트
 type GameStateUpdatePayload struct {
-	Players   []PlayerStateInfo `json:"players"`
-	TimeLeft  int               `json:"time_left"`
-	GameState interface{}       `json:"game_specific_state,omitempty"`
+	Players    []PlayerStateInfo `json:"players"`
+	TimeLeft   int               `json:"time_left"`
+	Tick       uint64            `json:"tick"`        // 상태 업데이트 순번, 누락 확인용
+	ServerTime int64             `json:"server_time"` // 서버 전송 시각 (Unix ms)
+	TeamScores map[string]int    `json:"team_scores,omitempty"`
+	GameState  interface{}       `json:"game_specific_state,omitempty"`
+}
+
+// 게임 상태 업데이트에 포함되는 게임 고유 상태
+type GameSpecificState struct {
+	Pings          []*PingMarker   `json:"pings"`
+	AttackEntities []*AttackEntity `json:"attack_entities"`   // 투사체, 지속 범위 효과
+	Arena          *ArenaState     `json:"arena,omitempty"`   // 경기장 축소 사용 시
+	Hazards        []*Hazard       `json:"hazards,omitempty"` // 낙하물 (낙하 예정 지점 포함)
 }
 
 // 게임 진행 중 플레이어 상태
@@ -132,24 +198,44 @@ type PlayerStateInfo struct {
 	Pitch            float64 `json:"pitch"`
 	Score            int     `json:"score"`
 	Asset            string  `json:"asset,omitempty"`
+	Team             string  `json:"team,omitempty"`
+	Character        string  `json:"character,omitempty"`
 	CurrentAnimation string  `json:"current_animation,omitempty"`
 	Health           int     `json:"health"`
 	MaxHealth        int     `json:"max_health"`
 	IsAlive          bool    `json:"is_alive"`
 	IsInvincible     bool    `json:"is_invincible"`
+	IsSlowed         bool    `json:"is_slowed,omitempty"`
+	IsBlocking       bool    `json:"is_blocking,omitempty"`
+	ChargeLevel      float64 `json:"charge_level,omitempty"` // 충전 공격 충전 정도 (0~1)
+	IsBot            bool    `json:"is_bot,omitempty"`
+	AbilityCooldown  int64   `json:"ability_cooldown_ms"` // 남은 능력 쿨타임
+	DashCooldown     int64   `json:"dash_cooldown_ms"`    // 남은 대시 쿨타임
+	ThrowCooldown    int64   `json:"throw_cooldown_ms"`   // 남은 던지기 쿨타임
+}
+
+// 게임 이벤트 (한 틱 동안 쌓인 이벤트)
+type GameEventPayload struct {
+	Events []GameEvent `json:"events"`
 }
 
 // 게임 종료 결과
 type GameEndedPayload struct {
-	FinalScores []PlayerScore `json:"final_scores"`
-	Reason      string        `json:"reason,omitempty"`
+	FinalScores []PlayerScore  `json:"final_scores"`
+	Reason      string         `json:"reason,omitempty"`
+	TeamScores  map[string]int `json:"team_scores,omitempty"`
+	WinningTeam string         `json:"winning_team,omitempty"` // 팀 모드에서 무승부일 경우 빈 값
+	Awards      []Award        `json:"awards,omitempty"`
+	Scoring     ScoringRules   `json:"scoring"` // 이번 게임에 적용된 점수 규칙
 }
 
 // 스코어
 type PlayerScore struct {
-	PlayerID string `json:"player_id"`
-	Nickname string `json:"nickname"`
-	Score    int    `json:"score"`
+	PlayerID string       `json:"player_id"`
+	Nickname string       `json:"nickname"`
+	Score    int          `json:"score"`
+	Team     string       `json:"team,omitempty"`
+	Stats    *CombatStats `json:"stats,omitempty"` // 전투 기록
 }
 
 // 새로운 Player 참여
@@ -174,6 +260,7 @@ type RoomStateUpdatedPayload struct {
 	RoomID   string       `json:"room_id"`
 	NewState RoomState    `json:"new_state"`
 	Players  []PlayerInfo `json:"players"`
+	Settings RoomSettings `json:"settings"`
 }
 
 // 메세지 타임스탬프 (추후 추가)
@@ -186,10 +273,62 @@ type TimestampedMessage struct {
 type GameInitDataPayload struct {
 	Players    []PlayerStateInfo `json:"players"`     // 모든 플레이어의 초기 상태
 	MapData    interface{}       `json:"map_data"`    // 맵 관련 데이터 (추후 추가)
-	GameConfig interface{}       `json:"game_config"` // 게임 설정 (추후 추가)
+	GameConfig interface{}       `json:"game_config"` // 게임 밸런스 (*Balance, 버전 포함)
 }
 
 // 게임 로딩 완료
 type GameLoadingCompletePayload struct {
 	PlayerID string `json:"player_id"`
 }
+
+// 리플레이 시청 요청
+type WatchReplayPayload struct {
+	ReplayID string  `json:"replay_id"`
+	Speed    float64 `json:"speed,omitempty"`
+}
+
+// 리플레이 재생 제어
+// action: pause, resume, seek, speed, stop
+type ReplayControlPayload struct {
+	Action     string  `json:"action"`
+	PositionMs int64   `json:"position_ms,omitempty"`
+	Speed      float64 `json:"speed,omitempty"`
+}
+
+// 리플레이 재생 상태
+type ReplayStatusPayload struct {
+	ReplayID   string  `json:"replay_id"`
+	PositionMs int64   `json:"position_ms"`
+	DurationMs int64   `json:"duration_ms"`
+	Speed      float64 `json:"speed"`
+	Paused     bool    `json:"paused"`
+	Finished   bool    `json:"finished"`
+}
+
+// 다른 노드에 있는 방 참가 시 리다이렉트
+// 클라이언트는 WsURL로 재접속 후 RoomID로 join_room 요청
+type RoomRedirectPayload struct {
+	RoomID string `json:"room_id"`
+	NodeID string `json:"node_id"`
+	WsURL  string `json:"ws_url"`
+}
+
+// 서버 종료 예고
+// 종료까지 매초 전송, SecondsLeft가 0이 되면 진행 중인 게임 종료 후 연결 해제
+type ServerShuttingDownPayload struct {
+	SecondsLeft int `json:"seconds_left"`
+}
+
+// 방 강제 종료 (관리자 등)
+// 클라이언트는 로비로 이동
+type RoomClosedPayload struct {
+	RoomID string `json:"room_id"`
+	Reason string `json:"reason"`
+}
+
+// 서버 공지
+type AnnouncementPayload struct {
+	Message   string               `json:"message"`
+	Severity  AnnouncementSeverity `json:"severity"`
+	ExpiresAt int64                `json:"expires_at,omitempty"` // Unix ms, 없으면 만료 없음
+}
@@ -0,0 +1,214 @@
+package backend
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 게임 틱 처리 시간 히스토그램 구간 (초)
+// 30FPS 기준 한 틱 예산은 약 33ms
+var tickDurationBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.02, 0.033, 0.05, 0.1}
+
+// 라벨 하나를 가진 카운터
+type counterVec struct {
+	mutex  sync.Mutex
+	values map[string]uint64
+}
+
+func newCounterVec() *counterVec {
+	return &counterVec{values: make(map[string]uint64)}
+}
+
+func (c *counterVec) Inc(label string) {
+	c.mutex.Lock()
+	c.values[label]++
+	c.mutex.Unlock()
+}
+
+// 라벨 이름순으로 정렬된 스냅샷
+func (c *counterVec) snapshot() ([]string, []uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	labels := make([]string, 0, len(c.values))
+	for label := range c.values {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	values := make([]uint64, len(labels))
+	for i, label := range labels {
+		values[i] = c.values[label]
+	}
+	return labels, values
+}
+
+// 누적 구간 히스토그램
+type histogram struct {
+	mutex   sync.Mutex
+	buckets []float64
+	counts  []uint64 // buckets와 같은 길이, 각 구간에 속한 관측 수 (누적 아님)
+	sum     float64
+	count   uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) Observe(value float64) {
+	h.mutex.Lock()
+	for i, upper := range h.buckets {
+		if value <= upper {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += value
+	h.count++
+	h.mutex.Unlock()
+}
+
+// 서버 전체 지표
+// 게이지(접속자 수, 방 수 등)는 수집 시점에 Server 상태에서 계산
+type Metrics struct {
+	messagesIn       *counterVec // 메세지 타입별 수신 수
+	messagesOut      *counterVec // 메세지 타입별 송신 수
+	droppedSends     *counterVec // send 채널이 가득 차서 버려진 메세지 수 (발생 위치별)
+	gameEnds         *counterVec // 게임 종료 사유별 수
+	rateLimited      *counterVec // 요청 제한으로 버려진 메세지 수 (제한 키별, 알 수 없는 타입은 other)
+	rejectedConns    *counterVec // 거부된 연결 수 (사유별)
+	suspiciousInputs *counterVec // 의심 입력 수 (사유별)
+	marshalErrors    atomic.Uint64
+	tickDuration     *histogram
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		messagesIn:       newCounterVec(),
+		messagesOut:      newCounterVec(),
+		droppedSends:     newCounterVec(),
+		gameEnds:         newCounterVec(),
+		rateLimited:      newCounterVec(),
+		rejectedConns:    newCounterVec(),
+		suspiciousInputs: newCounterVec(),
+		tickDuration:     newHistogram(tickDurationBuckets),
+	}
+}
+
+// 패키지 전역 지표
+var metrics = newMetrics()
+
+// 송신 메세지 타입 추출
+// Message는 항상 {"type":"..." 로 시작하므로 전체를 파싱하지 않고 앞부분만 확인
+func outgoingMessageType(messageBytes []byte) string {
+	const prefix = `{"type":"`
+	if !bytes.HasPrefix(messageBytes, []byte(prefix)) {
+		return "unknown"
+	}
+	rest := messageBytes[len(prefix):]
+	end := bytes.IndexByte(rest, '"')
+	if end < 0 {
+		return "unknown"
+	}
+	return string(rest[:end])
+}
+
+// 틱 처리 시간 기록
+func (m *Metrics) observeTick(d time.Duration) {
+	m.tickDuration.Observe(d.Seconds())
+}
+
+// 지표 HTTP 핸들러 (Prometheus text format)
+// GET /metrics
+func ServeMetrics(server *Server, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	server.writeMetrics(w)
+}
+
+func (s *Server) writeMetrics(w io.Writer) {
+	// 게이지 계산
+	roomsByState := map[RoomState]int{
+		RoomStateWaiting:  0,
+		RoomStatePlaying:  0,
+		RoomStateFinished: 0,
+	}
+	activeGames := 0
+
+	s.mutex.RLock()
+	clients := len(s.clients)
+	s.mutex.RUnlock()
+
+	// s.mutex와 r.mutex를 겹쳐 잡지 않도록 방 목록 스냅샷 후 따로 조회
+	for _, room := range s.roomSnapshot() {
+		room.mutex.RLock()
+		roomsByState[room.state]++
+		if room.game != nil {
+			activeGames++
+		}
+		room.mutex.RUnlock()
+	}
+
+	writeHeader(w, "game_connected_clients", "gauge", "Number of connected WebSocket clients.")
+	fmt.Fprintf(w, "game_connected_clients %d\n", clients)
+
+	writeHeader(w, "game_rooms", "gauge", "Number of rooms by state.")
+	for _, state := range []RoomState{RoomStateWaiting, RoomStatePlaying, RoomStateFinished} {
+		fmt.Fprintf(w, "game_rooms{state=%q} %d\n", state, roomsByState[state])
+	}
+
+	writeHeader(w, "game_active_games", "gauge", "Number of games in progress.")
+	fmt.Fprintf(w, "game_active_games %d\n", activeGames)
+
+	writeCounterVec(w, "game_messages_received_total", "Messages received from clients by type.", "type", metrics.messagesIn)
+	writeCounterVec(w, "game_messages_sent_total", "Messages written to clients by type.", "type", metrics.messagesOut)
+	writeCounterVec(w, "game_dropped_sends_total", "Messages dropped because a client send buffer was full.", "source", metrics.droppedSends)
+	writeCounterVec(w, "game_ended_total", "Finished games by end reason.", "reason", metrics.gameEnds)
+	writeCounterVec(w, "game_rate_limited_messages_total", "Client messages dropped by rate limiting by type.", "type", metrics.rateLimited)
+	writeCounterVec(w, "game_suspicious_inputs_total", "Player inputs that failed server-side validation by reason.", "reason", metrics.suspiciousInputs)
+	writeCounterVec(w, "game_rejected_connections_total", "WebSocket connections rejected before upgrade by reason.", "reason", metrics.rejectedConns)
+
+	writeHeader(w, "game_marshal_errors_total", "counter", "Failed JSON encodings of outgoing messages.")
+	fmt.Fprintf(w, "game_marshal_errors_total %d\n", metrics.marshalErrors.Load())
+
+	writeHistogram(w, "game_tick_duration_seconds", "Time spent updating and broadcasting one game tick.", metrics.tickDuration)
+}
+
+func writeHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeCounterVec(w io.Writer, name, help, labelName string, c *counterVec) {
+	writeHeader(w, name, "counter", help)
+	labels, values := c.snapshot()
+	for i, label := range labels {
+		fmt.Fprintf(w, "%s{%s=\"%s\"} %d\n", name, labelName, escapeLabelValue(label), values[i])
+	}
+}
+
+func writeHistogram(w io.Writer, name, help string, h *histogram) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	writeHeader(w, name, "histogram", help)
+	var cumulative uint64
+	for i, upper := range h.buckets {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{le=\"%g\"} %d\n", name, upper, cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, h.count)
+	fmt.Fprintf(w, "%s_sum %g\n", name, h.sum)
+	fmt.Fprintf(w, "%s_count %d\n", name, h.count)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,124 @@
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 전역 지표를 새로 만들고 테스트가 끝나면 되돌림
+func useTestMetrics(t *testing.T) {
+	t.Helper()
+	previous := metrics
+	metrics = newMetrics()
+	t.Cleanup(func() { metrics = previous })
+}
+
+func TestServeMetrics(t *testing.T) {
+	useTestMetrics(t)
+
+	s := &Server{clients: make(map[string]*Client), rooms: make(map[string]*Room)}
+	s.clients["c1"] = &Client{id: "c1"}
+	s.clients["c2"] = &Client{id: "c2"}
+	s.rooms["ROOM01"] = &Room{id: "ROOM01", state: RoomStateWaiting, clients: make(map[*Client]bool)}
+	s.rooms["ROOM02"] = &Room{id: "ROOM02", state: RoomStatePlaying, clients: make(map[*Client]bool), game: &Game{}}
+
+	metrics.messagesIn.Inc("join_room")
+	metrics.messagesIn.Inc("join_room")
+	metrics.droppedSends.Inc("room")
+	metrics.marshalErrors.Add(3)
+	metrics.observeTick(200 * time.Microsecond) // 0.0005 구간
+	metrics.observeTick(4 * time.Millisecond)   // 0.005 구간
+	metrics.observeTick(time.Second)            // +Inf
+
+	rec := httptest.NewRecorder()
+	ServeMetrics(s, rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	body := rec.Body.String()
+
+	for _, line := range []string{
+		"# TYPE game_connected_clients gauge",
+		"game_connected_clients 2",
+		`game_rooms{state="waiting"} 1`,
+		`game_rooms{state="playing"} 1`,
+		`game_rooms{state="finished"} 0`,
+		"game_active_games 1",
+		"# TYPE game_messages_received_total counter",
+		`game_messages_received_total{type="join_room"} 2`,
+		`game_dropped_sends_total{source="room"} 1`,
+		"game_marshal_errors_total 3",
+		"# TYPE game_tick_duration_seconds histogram",
+		`game_tick_duration_seconds_bucket{le="0.0005"} 1`,
+		`game_tick_duration_seconds_bucket{le="0.0025"} 1`,
+		`game_tick_duration_seconds_bucket{le="0.005"} 2`,
+		`game_tick_duration_seconds_bucket{le="0.1"} 2`,
+		`game_tick_duration_seconds_bucket{le="+Inf"} 3`,
+		"game_tick_duration_seconds_count 3",
+	} {
+		if !containsLine(body, line) {
+			t.Errorf("metrics output missing line %q", line)
+		}
+	}
+}
+
+func TestWriteCounterVecEscapesLabels(t *testing.T) {
+	c := newCounterVec()
+	c.Inc(`quote"back\slash` + "\nnewline")
+
+	var out strings.Builder
+	writeCounterVec(&out, "test_total", "Test counter.", "reason", c)
+
+	want := `test_total{reason="quote\"back\\slash\nnewline"} 1`
+	if !containsLine(out.String(), want) {
+		t.Fatalf("output = %q, want line %q", out.String(), want)
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", "plain"},
+		{`a"b`, `a\"b`},
+		{`a\b`, `a\\b`},
+		{"a\nb", `a\nb`},
+		{`\"`, `\\\"`},
+	}
+	for _, tt := range tests {
+		if got := escapeLabelValue(tt.value); got != tt.want {
+			t.Errorf("escapeLabelValue(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestOutgoingMessageType(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{`{"type":"game_state","payload":{}}`, "game_state"},
+		{`{"payload":{},"type":"game_state"}`, "unknown"},
+		{`{"type":"unterminated`, "unknown"},
+		{``, "unknown"},
+	}
+	for _, tt := range tests {
+		if got := outgoingMessageType([]byte(tt.message)); got != tt.want {
+			t.Errorf("outgoingMessageType(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func containsLine(body, line string) bool {
+	for _, l := range strings.Split(body, "\n") {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package backend
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	minNicknameLen   = 1
+	maxNicknameLen   = 16
+	defaultCharacter = "onion"
+)
+
+// 에러 코드
+// 클라이언트가 메세지 문구 대신 코드로 분기할 수 있도록 함께 전송
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidNickname       ErrorCode = "invalid_nickname"
+	ErrorCodeInappropriateNickname ErrorCode = "inappropriate_nickname"
+	ErrorCodeNicknameTaken         ErrorCode = "nickname_taken"
+	ErrorCodeInvalidColor          ErrorCode = "invalid_color"
+	ErrorCodeUnknownCharacter      ErrorCode = "unknown_character"
+)
+
+// 캐릭터 정보
+type CharacterInfo struct {
+	ID      string         `json:"id"`
+	Name    string         `json:"name"`  // 표시 이름
+	Emoji   string         `json:"emoji"` // 목록, 결과 화면 표시용
+	Asset   string         `json:"asset"` // 클라이언트 모델 파일
+	Stats   CharacterStats `json:"stats"`
+	Ability AbilityInfo    `json:"ability"` // 고유 능력
+}
+
+// 서버 캐릭터 목록
+// 새 캐릭터는 모델 파일을 static/assets에 추가한 뒤 여기에 등록
+var characterCatalog = []CharacterInfo{
+	{
+		// 기본형
+		ID: "onion", Name: "양파", Emoji: "🧅", Asset: "onion.glb",
+		Stats:   CharacterStats{Speed: 1.0, HealthBonus: 0, Reach: 1.0, Cooldown: 1.0},
+		Ability: abilities[abilityTearCloud],
+	},
+	{
+		// 느리지만 단단하고 긴 망치
+		ID: "potato", Name: "감자", Emoji: "🥔", Asset: "potato.glb",
+		Stats:   CharacterStats{Speed: 0.85, HealthBonus: 1, Reach: 1.15, Cooldown: 1.2},
+		Ability: abilities[abilityGroundSlam],
+	},
+	{
+		// 빠르고 약함
+		ID: "tomato", Name: "토마토", Emoji: "🍅", Asset: "tomato.glb",
+		Stats:   CharacterStats{Speed: 1.15, HealthBonus: -1, Reach: 0.9, Cooldown: 0.9},
+		Ability: abilities[abilityRoll],
+	},
+	{
+		// 짧은 망치를 빠르게 휘두름
+		ID: "paprika", Name: "파프리카", Emoji: "🫑", Asset: "paprika.glb",
+		Stats:   CharacterStats{Speed: 1.05, HealthBonus: 0, Reach: 0.85, Cooldown: 0.75},
+		Ability: abilities[abilitySpicyBurst],
+	},
+}
+
+// 캐릭터 조회
+func lookupCharacter(id string) (CharacterInfo, bool) {
+	for _, character := range characterCatalog {
+		if character.ID == id {
+			return character, true
+		}
+	}
+	return CharacterInfo{}, false
+}
+
+// 캐릭터 정보
+// 목록에 없으면 기본 캐릭터 사용
+func characterOrDefault(id string) CharacterInfo {
+	if character, ok := lookupCharacter(id); ok {
+		return character
+	}
+	character, _ := lookupCharacter(defaultCharacter)
+	return character
+}
+
+// 무작위 캐릭터 (봇용)
+func randomCharacter() string {
+	return characterCatalog[rand.Intn(len(characterCatalog))].ID
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// 닉네임에 쓸 수 없는 단어
+// 공백, 구두점을 지우고 소문자로 바꾼 닉네임에서 부분 일치로 검사
+var bannedNicknameWords = []string{
+	"fuck", "shit", "bitch", "cunt", "nigger", "faggot",
+	"시발", "씨발", "ㅅㅂ", "병신", "ㅂㅅ", "개새끼", "좆", "존나", "지랄",
+}
+
+// 닉네임에 쓸 수 없는 이름 (대소문자 무시)
+var reservedNicknames = []string{defaultClientID, "admin", "server", "system"}
+
+// 닉네임 검증
+// 앞뒤 공백을 제거한 닉네임 반환
+func validateNickname(nickname string) (string, ErrorCode, string) {
+	nickname = strings.TrimSpace(nickname)
+	length := utf8.RuneCountInString(nickname)
+	if length < minNicknameLen || length > maxNicknameLen {
+		return "", ErrorCodeInvalidNickname, "닉네임은 1~16자로 입력해주세요."
+	}
+
+	previousSpace := false
+	for _, r := range nickname {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_', r == '-', r == '.':
+			previousSpace = false
+		case r == ' ':
+			if previousSpace {
+				return "", ErrorCodeInvalidNickname, "닉네임에 공백을 연속으로 쓸 수 없습니다."
+			}
+			previousSpace = true
+		default:
+			return "", ErrorCodeInvalidNickname, "닉네임에는 글자, 숫자, 공백, _ - . 만 쓸 수 있습니다."
+		}
+	}
+
+	for _, reserved := range reservedNicknames {
+		if strings.EqualFold(nickname, reserved) {
+			return "", ErrorCodeInvalidNickname, "사용할 수 없는 닉네임입니다."
+		}
+	}
+
+	compact := strings.ToLower(strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' || r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, nickname))
+	for _, word := range bannedNicknameWords {
+		if strings.Contains(compact, word) {
+			return "", ErrorCodeInappropriateNickname, "부적절한 단어가 포함된 닉네임입니다."
+		}
+	}
+
+	return nickname, "", ""
+}
+
+// 색상 검증 (#RRGGBB)
+func isValidColor(color string) bool {
+	return hexColorPattern.MatchString(color)
+}
+
+// 방 안에서 닉네임이 이미 쓰이고 있는지 확인 (대소문자 무시)
+// 프로필을 설정하지 않은 기본 닉네임끼리는 중복으로 보지 않음
+// r.mutex Lock 상태에서 호출
+func (r *Room) isNicknameTaken(nickname string, except *Client) bool {
+	if nickname == defaultClientID {
+		return false
+	}
+	for c := range r.clients {
+		if c != except && strings.EqualFold(c.nickname, nickname) {
+			return true
+		}
+	}
+	return false
+}
+
+// 코드가 있는 에러 전송
+func (s *Server) sendCodedError(client *Client, code ErrorCode, message string) {
+	s.sendErrorPayload(client, ErrorPayload{Code: code, Message: message})
+}
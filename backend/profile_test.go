@@ -0,0 +1,107 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateNickname(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		wantCode ErrorCode
+	}{
+		{"plain", "potato", "potato", ""},
+		{"trims spaces", "  감자 왕  ", "감자 왕", ""},
+		{"allowed punctuation", "veg_gie-1.0", "veg_gie-1.0", ""},
+		{"max length", strings.Repeat("가", maxNicknameLen), strings.Repeat("가", maxNicknameLen), ""},
+		{"empty", "   ", "", ErrorCodeInvalidNickname},
+		{"too long", strings.Repeat("a", maxNicknameLen+1), "", ErrorCodeInvalidNickname},
+		{"double space", "veggie  king", "", ErrorCodeInvalidNickname},
+		{"symbol", "veggie!", "", ErrorCodeInvalidNickname},
+		{"emoji", "🥔potato", "", ErrorCodeInvalidNickname},
+		{"reserved default", "anonymous", "", ErrorCodeInvalidNickname},
+		{"reserved admin", "Admin", "", ErrorCodeInvalidNickname},
+		{"profanity", "xFuCkx", "", ErrorCodeInappropriateNickname},
+		{"profanity split by punctuation", "시.발", "", ErrorCodeInappropriateNickname},
+		{"profanity split by space", "병 신", "", ErrorCodeInappropriateNickname},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, code, _ := validateNickname(tt.input)
+			if code != tt.wantCode {
+				t.Fatalf("validateNickname(%q) code = %q, want %q", tt.input, code, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Fatalf("validateNickname(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  bool
+	}{
+		{"#e17055", true},
+		{"#FFFFFF", true},
+		{"#000000", true},
+		{"e17055", false},
+		{"#fff", false},
+		{"#e1705g", false},
+		{"#e170555", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isValidColor(tt.color); got != tt.want {
+			t.Errorf("isValidColor(%q) = %v, want %v", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestLookupCharacter(t *testing.T) {
+	for _, character := range characterCatalog {
+		if _, ok := lookupCharacter(character.ID); !ok {
+			t.Errorf("lookupCharacter(%q) not found", character.ID)
+		}
+	}
+	if _, ok := lookupCharacter("broccoli"); ok {
+		t.Error("lookupCharacter(\"broccoli\") found unknown character")
+	}
+	if got := characterOrDefault("broccoli").ID; got != defaultCharacter {
+		t.Errorf("characterOrDefault(\"broccoli\") = %q, want %q", got, defaultCharacter)
+	}
+}
+
+func TestRoomIsNicknameTaken(t *testing.T) {
+	self := &Client{id: "self", nickname: "potato"}
+	other := &Client{id: "other", nickname: "Tomato"}
+	anonymous := &Client{id: "anonymous", nickname: defaultClientID}
+	room := &Room{clients: map[*Client]bool{self: true, other: true, anonymous: true}}
+
+	tests := []struct {
+		name     string
+		nickname string
+		except   *Client
+		want     bool
+	}{
+		{"other member", "Tomato", self, true},
+		{"case insensitive", "tomato", self, true},
+		{"own nickname", "potato", self, false},
+		{"free nickname", "onion", self, false},
+		{"joining client", "potato", nil, true},
+		{"default nicknames do not collide", defaultClientID, &Client{id: "new", nickname: defaultClientID}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := room.isNicknameTaken(tt.nickname, tt.except); got != tt.want {
+				t.Fatalf("isNicknameTaken(%q) = %v, want %v", tt.nickname, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package backend
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// 초과 요청 누적 집계 구간
+	rateLimitWindow = 10 * time.Second
+	// 구간 안에서 이만큼 버려지면 경고 메세지 전송
+	rateLimitWarnAfter = 20
+	// 구간 안에서 이만큼 버려지면 연결 종료
+	rateLimitDisconnectAfter = 200
+
+	rateLimitCloseReason = "rate limit exceeded"
+)
+
+// 토큰 버킷 규칙
+// 초당 rate개 충전, 최대 burst개까지 누적
+type rateLimitRule struct {
+	rate  float64
+	burst float64
+}
+
+// 메세지 종류별 제한
+// player_action은 "player_action:<action_type>" 키 사용
+var rateLimitRules = map[string]rateLimitRule{
+	// 마우스 이동마다 전송되므로 넉넉하게
+	"player_action:look": {rate: 150, burst: 300},
+	"player_action:move": {rate: 30, burst: 60},
+	// 쿨타임보다 빠른 클릭은 어차피 무시되지만 게임 Lock을 잡으므로 제한
+	"player_action:click":          {rate: 10, burst: 20},
+	"player_action:charge_start":   {rate: 10, burst: 20},
+	"player_action:charge_release": {rate: 10, burst: 20},
+	"player_action:block":          {rate: 10, burst: 20},
+	"player_action:emote":          {rate: 3, burst: 6},
+	"player_action:ping":           {rate: 3, burst: 6},
+	// 쿨타임이 길어서 연타할 이유가 없음
+	"player_action:ability": {rate: 2, burst: 4},
+	"player_action:dash":    {rate: 3, burst: 6},
+	"player_action:throw":   {rate: 3, burst: 6},
+	"player_action":         {rate: 10, burst: 20}, // 그 외 액션
+
+	string(MessageTypeGameLoadingComplete): {rate: 1, burst: 3},
+	string(MessageTypeCreateRoom):          {rate: 0.5, burst: 3},
+	string(MessageTypeJoinRoom):            {rate: 1, burst: 3},
+	string(MessageTypeSetNicknameColor):    {rate: 1, burst: 3},
+	string(MessageTypeListRooms):           {rate: 2, burst: 5},
+	string(MessageTypeWatchReplay):         {rate: 1, burst: 3},
+	string(MessageTypeReplayControl):       {rate: 5, burst: 10},
+}
+
+// 목록에 없는 메세지 종류 (방 설정, 준비, 봇 관리 등)
+var defaultRateLimitRule = rateLimitRule{rate: 5, burst: 10}
+
+// 기본 규칙으로 따로 제한하는 메세지 종류
+var defaultRuleMessageTypes = map[MessageType]bool{
+	MessageTypeLeaveRoom:          true,
+	MessageTypeReadyToggle:        true,
+	MessageTypeStartGame:          true,
+	MessageTypeUpdateRoomSettings: true,
+	MessageTypeSetTeam:            true,
+	MessageTypeAutoBalanceTeams:   true,
+	MessageTypeAddBot:             true,
+	MessageTypeRemoveBot:          true,
+}
+
+// 그 외 메세지 종류 (알 수 없는 타입, 잘못된 JSON)
+// 클라이언트가 정하는 값이라 종류마다 버킷을 만들면 제한을 피하고 메모리가 계속 늘어나므로 하나로 묶음
+const rateLimitOtherKey = "other"
+
+type tokenBucket struct {
+	rule   rateLimitRule
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rule rateLimitRule, now time.Time) *tokenBucket {
+	return &tokenBucket{rule: rule, tokens: rule.burst, last: now}
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.tokens = min(b.rule.burst, b.tokens+now.Sub(b.last).Seconds()*b.rule.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// 초과 요청 처리 단계
+type rateLimitAction int
+
+const (
+	rateLimitAllow      rateLimitAction = iota
+	rateLimitDrop                       // 메세지만 버림
+	rateLimitWarn                       // 버리고 클라이언트에게 경고
+	rateLimitDisconnect                 // 연결 종료
+)
+
+// 클라이언트별 요청 제한
+// readPump 고루틴에서만 접근하므로 Lock 없음
+type clientRateLimiter struct {
+	buckets     map[string]*tokenBucket
+	windowStart time.Time
+	dropped     int  // 현재 구간에서 버려진 메세지 수
+	warned      bool // 현재 구간에서 경고를 보냈는지
+}
+
+func newClientRateLimiter() *clientRateLimiter {
+	return &clientRateLimiter{buckets: make(map[string]*tokenBucket)}
+}
+
+// 메세지 제한 키
+// 버킷과 메트릭 라벨에 쓰이므로 정해진 값만 반환
+func rateLimitKey(msg *Message) string {
+	if msg.Type != MessageTypePlayerAction {
+		if _, ok := rateLimitRules[string(msg.Type)]; ok || defaultRuleMessageTypes[msg.Type] {
+			return string(msg.Type)
+		}
+		return rateLimitOtherKey
+	}
+	if payload, ok := msg.Payload.(map[string]interface{}); ok {
+		if actionType, ok := payload["action_type"].(string); ok {
+			key := string(MessageTypePlayerAction) + ":" + actionType
+			if _, known := rateLimitRules[key]; known {
+				return key
+			}
+		}
+	}
+	return string(MessageTypePlayerAction)
+}
+
+// 메세지 허용 여부와 초과 시 처리 단계
+func (l *clientRateLimiter) check(key string, now time.Time) rateLimitAction {
+	bucket, ok := l.buckets[key]
+	if !ok {
+		rule, known := rateLimitRules[key]
+		if !known {
+			rule = defaultRateLimitRule
+		}
+		bucket = newTokenBucket(rule, now)
+		l.buckets[key] = bucket
+	}
+	if bucket.allow(now) {
+		return rateLimitAllow
+	}
+
+	if now.Sub(l.windowStart) >= rateLimitWindow {
+		l.windowStart = now
+		l.dropped = 0
+		l.warned = false
+	}
+	l.dropped++
+
+	switch {
+	case l.dropped >= rateLimitDisconnectAfter:
+		return rateLimitDisconnect
+	case l.dropped >= rateLimitWarnAfter && !l.warned:
+		l.warned = true
+		return rateLimitWarn
+	}
+	return rateLimitDrop
+}
+
+// 요청 제한 적용
+// 메세지를 처리해도 되면 true
+// readPump에서 호출
+func (c *Client) allowMessage(msg *Message) bool {
+	key := rateLimitKey(msg)
+	action := c.rateLimiter.check(key, time.Now())
+	if action == rateLimitAllow {
+		return true
+	}
+
+	metrics.rateLimited.Inc(key)
+	switch action {
+	case rateLimitWarn:
+		c.logger.Warn("Client is sending too many messages", "key", key, "dropped", c.rateLimiter.dropped)
+		c.server.sendError(c, "요청이 너무 많습니다. 계속되면 연결이 종료됩니다.")
+	case rateLimitDisconnect:
+		c.logger.Warn("Disconnecting flooding client", "key", key, "dropped", c.rateLimiter.dropped)
+		c.Disconnect(websocket.ClosePolicyViolation, rateLimitCloseReason)
+	}
+	return false
+}
+
+// 연결 IP (프록시 헤더는 신뢰하지 않음)
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// IP별 연결 수 예약
+// 제한을 넘으면 false
+func (s *Server) acquireIPSlot(ip string) bool {
+	maxPerIP := s.config.Limits.MaxConnectionsPerIP
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if maxPerIP > 0 && s.connectionsByIP[ip] >= maxPerIP {
+		return false
+	}
+	s.connectionsByIP[ip]++
+	return true
+}
+
+// IP별 연결 수 반환
+func (s *Server) releaseIPSlot(ip string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.connectionsByIP[ip] <= 1 {
+		delete(s.connectionsByIP, ip)
+		return
+	}
+	s.connectionsByIP[ip]--
+}
@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketRefill(t *testing.T) {
+	start := time.Unix(0, 0)
+	rule := rateLimitRule{rate: 2, burst: 4}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration // 버스트를 다 쓴 뒤 지난 시간
+		allowed int           // 그 뒤 연속으로 허용되는 수
+	}{
+		{"no refill", 0, 0},
+		{"partial token", 400 * time.Millisecond, 0},
+		{"one token", 500 * time.Millisecond, 1},
+		{"two tokens", time.Second, 2},
+		{"capped at burst", time.Minute, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newTokenBucket(rule, start)
+			for i := 0; i < int(rule.burst); i++ {
+				if !bucket.allow(start) {
+					t.Fatalf("burst message %d denied", i)
+				}
+			}
+
+			now := start.Add(tt.elapsed)
+			allowed := 0
+			for bucket.allow(now) {
+				allowed++
+			}
+			if allowed != tt.allowed {
+				t.Fatalf("allowed after %v = %d, want %d", tt.elapsed, allowed, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestClientRateLimiterEscalation(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newClientRateLimiter()
+	key := string(MessageTypeCreateRoom)
+	burst := int(rateLimitRules[key].burst)
+
+	for i := 0; i < burst; i++ {
+		if action := limiter.check(key, now); action != rateLimitAllow {
+			t.Fatalf("burst message %d = %v, want allow", i, action)
+		}
+	}
+
+	// 같은 시각에 계속 보내면 drop -> warn (한 번) -> drop -> disconnect
+	for dropped := 1; dropped <= rateLimitDisconnectAfter; dropped++ {
+		want := rateLimitDrop
+		switch {
+		case dropped == rateLimitDisconnectAfter:
+			want = rateLimitDisconnect
+		case dropped == rateLimitWarnAfter:
+			want = rateLimitWarn
+		}
+		if action := limiter.check(key, now); action != want {
+			t.Fatalf("dropped message %d = %v, want %v", dropped, action, want)
+		}
+	}
+}
+
+func TestClientRateLimiterWindowReset(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newClientRateLimiter()
+	key := string(MessageTypeCreateRoom)
+	for i := 0; i < int(rateLimitRules[key].burst)+rateLimitWarnAfter; i++ {
+		limiter.check(key, now)
+	}
+	if !limiter.warned {
+		t.Fatal("limiter did not warn inside the window")
+	}
+
+	// 구간이 지나면 누적 수와 경고 여부 초기화
+	later := now.Add(rateLimitWindow)
+	limiter.buckets[key].tokens = 0
+	limiter.buckets[key].last = later
+	if action := limiter.check(key, later); action != rateLimitDrop {
+		t.Fatalf("first drop in new window = %v, want drop", action)
+	}
+	if limiter.dropped != 1 || limiter.warned {
+		t.Fatalf("window not reset: dropped=%d warned=%v", limiter.dropped, limiter.warned)
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"rule type", Message{Type: MessageTypeCreateRoom}, string(MessageTypeCreateRoom)},
+		{"default rule type", Message{Type: MessageTypeReadyToggle}, string(MessageTypeReadyToggle)},
+		{"unknown type", Message{Type: "x_1234"}, rateLimitOtherKey},
+		{"invalid json", Message{Type: "invalid_json"}, rateLimitOtherKey},
+		{"known action", Message{Type: MessageTypePlayerAction, Payload: map[string]interface{}{"action_type": "move"}}, "player_action:move"},
+		{"unknown action", Message{Type: MessageTypePlayerAction, Payload: map[string]interface{}{"action_type": "x_1234"}}, string(MessageTypePlayerAction)},
+		{"action without payload", Message{Type: MessageTypePlayerAction}, string(MessageTypePlayerAction)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rateLimitKey(&tt.msg); got != tt.want {
+				t.Fatalf("rateLimitKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRateLimiterRandomTypesShareBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newClientRateLimiter()
+
+	// 매번 다른 타입을 보내도 other 버킷 하나로 제한
+	denied := false
+	for i := 0; i < 100; i++ {
+		msg := Message{Type: MessageType("random_" + time.Duration(i).String())}
+		if limiter.check(rateLimitKey(&msg), now) != rateLimitAllow {
+			denied = true
+		}
+	}
+	if !denied {
+		t.Fatal("random message types were never rate limited")
+	}
+	if len(limiter.buckets) != 1 {
+		t.Fatalf("buckets = %d, want 1", len(limiter.buckets))
+	}
+}
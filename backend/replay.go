@@ -0,0 +1,627 @@
+package backend
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	replayFileExt     = ".replay.gz"
+	replayMetaExt     = ".json"
+	replayVersion     = 1
+	maxStoredReplays  = 200              // 보관하는 최대 리플레이 수, 초과 시 오래된 것부터 삭제
+	replayStatusEvery = 1 * time.Second  // 재생 중 상태 메세지 전송 주기
+	minReplaySpeed    = 0.25             // 최소 재생 속도
+	maxReplaySpeed    = 8.0              // 최대 재생 속도
+	replayIdleTimeout = 10 * time.Minute // 일시정지/종료 상태로 유지되는 최대 시간
+)
+
+// 리플레이 요약 정보
+// 리플레이 파일 옆에 JSON으로 저장
+type ReplayInfo struct {
+	ID         string        `json:"id"`
+	Version    int           `json:"version"`
+	RoomID     string        `json:"room_id"`
+	StartedAt  time.Time     `json:"started_at"`
+	EndedAt    time.Time     `json:"ended_at"`
+	DurationMs int64         `json:"duration_ms"`
+	Frames     int           `json:"frames"`
+	Reason     string        `json:"reason,omitempty"`
+	Players    []PlayerScore `json:"players"`
+	// 게임에 적용된 밸런스 버전 (진행 중 바뀌었으면 적용 순서대로)
+	BalanceVersions []string `json:"balance_versions,omitempty"`
+	SizeBytes       int64    `json:"size_bytes"`
+}
+
+// 리플레이 한 프레임
+// T는 녹화 시작부터의 경과 시간 (ms), M은 클라이언트에게 보냈던 메세지 원문
+type replayFrame struct {
+	T int64           `json:"t"`
+	M json.RawMessage `json:"m"`
+}
+
+// 리플레이 저장소
+type ReplayStore struct {
+	dir    string
+	mutex  sync.Mutex
+	logger *slog.Logger
+}
+
+// 리플레이 저장소 생성
+func NewReplayStore(dir string, logger *slog.Logger) (*ReplayStore, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create replay dir %s: %w", dir, err)
+	}
+	return &ReplayStore{dir: dir, logger: logger}, nil
+}
+
+func (s *ReplayStore) replayPath(id string) string {
+	return filepath.Join(s.dir, id+replayFileExt)
+}
+
+func (s *ReplayStore) metaPath(id string) string {
+	return filepath.Join(s.dir, id+replayMetaExt)
+}
+
+// 리플레이 목록 (최신순)
+func (s *ReplayStore) List() ([]ReplayInfo, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.listLocked()
+}
+
+func (s *ReplayStore) listLocked() ([]ReplayInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]ReplayInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), replayMetaExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			s.logger.Warn("Failed to read replay meta", "file", entry.Name(), "error", err)
+			continue
+		}
+		var info ReplayInfo
+		if err := json.Unmarshal(data, &info); err != nil {
+			s.logger.Warn("Invalid replay meta", "file", entry.Name(), "error", err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].StartedAt.After(infos[j].StartedAt) })
+	return infos, nil
+}
+
+// 리플레이 요약 정보 조회
+func (s *ReplayStore) Info(id string) (ReplayInfo, error) {
+	var info ReplayInfo
+	if !safeIDPattern.MatchString(id) {
+		return info, os.ErrNotExist
+	}
+	data, err := os.ReadFile(s.metaPath(id))
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
+
+// 리플레이 프레임 전체 로드
+func (s *ReplayStore) Load(id string) ([]replayFrame, error) {
+	if !safeIDPattern.MatchString(id) {
+		return nil, os.ErrNotExist
+	}
+	f, err := os.Open(s.replayPath(id))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	frames := make([]replayFrame, 0, 1024)
+	decoder := json.NewDecoder(gz)
+	for {
+		var frame replayFrame
+		if err := decoder.Decode(&frame); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// 오래된 리플레이 정리
+func (s *ReplayStore) prune() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	infos, err := s.listLocked()
+	if err != nil || len(infos) <= maxStoredReplays {
+		return
+	}
+	for _, info := range infos[maxStoredReplays:] {
+		os.Remove(s.replayPath(info.ID))
+		os.Remove(s.metaPath(info.ID))
+		s.logger.Info("Pruned old replay", "replay_id", info.ID)
+	}
+}
+
+// 게임 녹화기
+// Room, gameLoop 고루틴 양쪽에서 호출되므로 mutex로 보호
+type replayRecorder struct {
+	store   *ReplayStore
+	info    ReplayInfo
+	file    *os.File
+	buffer  *bufio.Writer
+	gz      *gzip.Writer
+	started time.Time
+	failed  bool
+	closed  bool
+	mutex   sync.Mutex
+	logger  *slog.Logger
+}
+
+// 녹화 시작
+func (s *ReplayStore) newRecorder(roomID string) (*replayRecorder, error) {
+	now := time.Now()
+	id := fmt.Sprintf("%s_%s", roomID, now.Format("20060102-150405"))
+
+	f, err := os.Create(s.replayPath(id))
+	if err != nil {
+		return nil, err
+	}
+	buffer := bufio.NewWriterSize(f, 64*1024)
+	gz, _ := gzip.NewWriterLevel(buffer, gzip.BestSpeed)
+
+	return &replayRecorder{
+		store: s,
+		info: ReplayInfo{
+			ID:        id,
+			Version:   replayVersion,
+			RoomID:    roomID,
+			StartedAt: now,
+		},
+		file:    f,
+		buffer:  buffer,
+		gz:      gz,
+		started: now,
+		logger:  s.logger.With("room_id", roomID, "replay_id", id),
+	}, nil
+}
+
+// 메세지 한 개 기록
+func (rec *replayRecorder) record(messageBytes []byte) {
+	if rec == nil {
+		return
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	if rec.failed || rec.closed {
+		return
+	}
+	elapsed := time.Since(rec.started).Milliseconds()
+	if _, err := fmt.Fprintf(rec.gz, `{"t":%d,"m":`, elapsed); err == nil {
+		rec.gz.Write(messageBytes)
+		_, err = rec.gz.Write([]byte("}\n"))
+		if err == nil {
+			rec.info.Frames++
+			return
+		}
+	}
+	rec.logger.Error("Replay write failed, recording disabled")
+	rec.failed = true
+}
+
+// 녹화 종료 및 요약 정보 저장
+// 적용된 밸런스 버전 기록
+func (rec *replayRecorder) addBalanceVersion(version string) {
+	if rec == nil {
+		return
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	if n := len(rec.info.BalanceVersions); n > 0 && rec.info.BalanceVersions[n-1] == version {
+		return
+	}
+	rec.info.BalanceVersions = append(rec.info.BalanceVersions, version)
+}
+
+func (rec *replayRecorder) close(reason string, scores []PlayerScore) {
+	if rec == nil {
+		return
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	if rec.closed {
+		return
+	}
+	rec.closed = true
+
+	rec.gz.Close()
+	rec.buffer.Flush()
+	stat, _ := rec.file.Stat()
+	rec.file.Close()
+
+	if rec.failed || rec.info.Frames == 0 {
+		os.Remove(rec.store.replayPath(rec.info.ID))
+		return
+	}
+
+	rec.info.EndedAt = time.Now()
+	rec.info.DurationMs = rec.info.EndedAt.Sub(rec.started).Milliseconds()
+	rec.info.Reason = reason
+	rec.info.Players = scores
+	if stat != nil {
+		rec.info.SizeBytes = stat.Size()
+	}
+
+	data, _ := json.Marshal(rec.info)
+	if err := os.WriteFile(rec.store.metaPath(rec.info.ID), data, 0o644); err != nil {
+		rec.logger.Error("Failed to write replay meta", "error", err)
+		return
+	}
+	rec.logger.Info("Replay saved", "frames", rec.info.Frames, "size_bytes", rec.info.SizeBytes)
+
+	go rec.store.prune()
+}
+
+// 리플레이 목록 HTTP 핸들러
+// GET /replays
+func ServeReplayList(server *Server, w http.ResponseWriter, r *http.Request) {
+	if server.replays == nil {
+		http.Error(w, "replays disabled", http.StatusNotFound)
+		return
+	}
+	infos, err := server.replays.List()
+	if err != nil {
+		server.logger.Error("Failed to list replays", "error", err)
+		http.Error(w, "failed to list replays", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(infos)
+}
+
+// 리플레이 다운로드 HTTP 핸들러
+// GET /replays/{id}
+func ServeReplayDownload(server *Server, w http.ResponseWriter, r *http.Request) {
+	if server.replays == nil {
+		http.Error(w, "replays disabled", http.StatusNotFound)
+		return
+	}
+	id := r.PathValue("id")
+	if _, err := server.replays.Info(id); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s%s"`, id, replayFileExt))
+	http.ServeFile(w, r, server.replays.replayPath(id))
+}
+
+// 리플레이 재생 제어 명령
+type replayCommand struct {
+	action     string
+	positionMs int64
+	speed      float64
+}
+
+// 리플레이 재생기
+// 클라이언트 한 명에게 녹화된 메세지를 시간에 맞춰 전송
+type replayPlayer struct {
+	client  *Client
+	info    ReplayInfo
+	frames  []replayFrame
+	control chan replayCommand
+	stop    chan struct{}
+	once    sync.Once
+}
+
+func newReplayPlayer(client *Client, info ReplayInfo, frames []replayFrame, speed float64) (*replayPlayer, float64) {
+	return &replayPlayer{
+		client:  client,
+		info:    info,
+		frames:  frames,
+		control: make(chan replayCommand, 8),
+		stop:    make(chan struct{}),
+	}, clampReplaySpeed(speed)
+}
+
+func clampReplaySpeed(speed float64) float64 {
+	if speed <= 0 {
+		return 1
+	}
+	if speed < minReplaySpeed {
+		return minReplaySpeed
+	}
+	if speed > maxReplaySpeed {
+		return maxReplaySpeed
+	}
+	return speed
+}
+
+// 재생 중지
+func (p *replayPlayer) Stop() {
+	p.once.Do(func() { close(p.stop) })
+}
+
+// 재생 루프
+func (p *replayPlayer) run(speed float64) {
+	defer p.Stop()
+
+	index := 0
+	paused := false
+	position := int64(0)
+	lastStatus := time.Now()
+
+	// 첫 프레임(게임 초기 데이터)는 바로 전송
+	if len(p.frames) > 0 {
+		p.sendRaw(p.frames[0].M)
+		index = 1
+	}
+	p.sendStatus(position, speed, paused, false)
+
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		finished := index >= len(p.frames)
+
+		var wait <-chan time.Time
+		if !paused && !finished {
+			delay := time.Duration(float64(p.frames[index].T-position)/speed) * time.Millisecond
+			timer.Reset(delay)
+			wait = timer.C
+		}
+
+		var idle <-chan time.Time
+		if paused || finished {
+			idle = time.After(replayIdleTimeout)
+		}
+
+		select {
+		case <-wait:
+			frame := p.frames[index]
+			p.sendRaw(frame.M)
+			position = frame.T
+			index++
+			if index >= len(p.frames) {
+				p.sendStatus(position, speed, paused, true)
+			} else if time.Since(lastStatus) >= replayStatusEvery {
+				p.sendStatus(position, speed, paused, false)
+				lastStatus = time.Now()
+			}
+
+		case cmd := <-p.control:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			switch cmd.action {
+			case "pause":
+				paused = true
+			case "resume":
+				paused = false
+			case "speed":
+				speed = clampReplaySpeed(cmd.speed)
+			case "seek":
+				index, position = p.seekIndex(cmd.positionMs)
+				// 탐색 위치 직전 상태를 바로 전송
+				if index > 1 {
+					p.sendRaw(p.frames[index-1].M)
+				}
+			case "stop":
+				return
+			}
+			p.sendStatus(position, speed, paused, index >= len(p.frames))
+
+		case <-idle:
+			p.client.logger.Info("Replay idle timeout", "replay_id", p.info.ID)
+			return
+
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// 위치(ms)에 해당하는 프레임 index
+// 첫 프레임(초기 데이터)은 제외
+func (p *replayPlayer) seekIndex(positionMs int64) (int, int64) {
+	if positionMs < 0 {
+		positionMs = 0
+	}
+	index := sort.Search(len(p.frames), func(i int) bool {
+		return i > 0 && p.frames[i].T >= positionMs
+	})
+	if index < 1 {
+		index = 1
+	}
+	if index >= len(p.frames) {
+		return len(p.frames), p.info.DurationMs
+	}
+	return index, p.frames[index].T
+}
+
+func (p *replayPlayer) sendRaw(messageBytes []byte) {
+	select {
+	case p.client.send <- messageBytes:
+	default:
+		metrics.droppedSends.Inc("replay")
+		if droppedSendLogSampler.Sample() {
+			p.client.logger.Warn("Send channel full, replay frame dropped", "replay_id", p.info.ID)
+		}
+	}
+}
+
+func (p *replayPlayer) sendStatus(position int64, speed float64, paused, finished bool) {
+	msg := Message{Type: MessageTypeReplayStatus, Payload: ReplayStatusPayload{
+		ReplayID:   p.info.ID,
+		PositionMs: position,
+		DurationMs: p.info.DurationMs,
+		Speed:      speed,
+		Paused:     paused,
+		Finished:   finished,
+	}}
+	payloadBytes, err := json.Marshal(msg)
+	if err != nil {
+		metrics.marshalErrors.Add(1)
+		return
+	}
+	p.sendRaw(payloadBytes)
+}
+
+// 리플레이 시청 요청 처리
+func (s *Server) handleWatchReplay(msg *Message) {
+	client := msg.Sender
+
+	if s.replays == nil {
+		s.sendError(client, "리플레이 기능이 비활성화되어 있습니다.")
+		return
+	}
+	if client.room != nil {
+		s.sendError(client, "방에 참여 중에는 리플레이를 볼 수 없습니다.")
+		return
+	}
+
+	var payload WatchReplayPayload
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		client.logger.Warn("Invalid watch replay payload", "error", err)
+		s.sendError(client, "잘못된 리플레이 요청입니다.")
+		return
+	}
+
+	info, err := s.replays.Info(payload.ReplayID)
+	if err != nil {
+		s.sendError(client, "존재하지 않는 리플레이입니다.")
+		return
+	}
+
+	// 파일 읽기, 압축 해제는 오래 걸릴 수 있으므로 run 고루틴 밖에서 처리
+	// 결과는 replayLoaded 채널로 run 고루틴에 돌려줌
+	go func() {
+		frames, err := s.replays.Load(info.ID)
+		s.replayLoaded <- &replayLoadResult{client: client, info: info, frames: frames, speed: payload.Speed, err: err}
+	}()
+}
+
+// 리플레이 로드 결과
+type replayLoadResult struct {
+	client *Client
+	info   ReplayInfo
+	frames []replayFrame
+	speed  float64
+	err    error
+}
+
+// 리플레이 로드 완료 처리
+// 로드하는 동안 연결이 끊겼거나 방에 들어갔으면 재생하지 않음
+// run 고루틴에서 호출
+func (s *Server) handleReplayLoaded(result *replayLoadResult) {
+	client := result.client
+
+	s.mutex.RLock()
+	_, registered := s.clients[client.id]
+	s.mutex.RUnlock()
+	if !registered {
+		return
+	}
+
+	if result.err != nil {
+		client.logger.Error("Failed to load replay", "replay_id", result.info.ID, "error", result.err)
+		s.sendError(client, "리플레이를 불러오지 못했습니다.")
+		return
+	}
+	if client.room != nil {
+		client.logger.Info("Replay loaded after joining a room, discarded", "replay_id", result.info.ID)
+		return
+	}
+
+	// 이미 보고 있는 리플레이는 중지
+	if client.replay != nil {
+		client.replay.Stop()
+	}
+
+	player, speed := newReplayPlayer(client, result.info, result.frames, result.speed)
+	s.setClientReplay(client, player)
+	go player.run(speed)
+
+	client.logger.Info("Started watching replay", "replay_id", result.info.ID, "frames", len(result.frames), "speed", speed)
+}
+
+// 리플레이 재생 제어 처리
+func (s *Server) handleReplayControl(msg *Message) {
+	client := msg.Sender
+	if client.replay == nil {
+		s.sendError(client, "재생 중인 리플레이가 없습니다.")
+		return
+	}
+
+	var payload ReplayControlPayload
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		client.logger.Warn("Invalid replay control payload", "error", err)
+		return
+	}
+
+	switch payload.Action {
+	case "pause", "resume", "seek", "speed", "stop":
+	default:
+		s.sendError(client, "알 수 없는 리플레이 제어 명령입니다.")
+		return
+	}
+
+	select {
+	case client.replay.control <- replayCommand{action: payload.Action, positionMs: payload.PositionMs, speed: payload.Speed}:
+	case <-client.replay.stop:
+		s.setClientReplay(client, nil)
+	default:
+		client.logger.Warn("Replay control channel full, command dropped", "action", payload.Action)
+	}
+
+	if payload.Action == "stop" {
+		s.setClientReplay(client, nil)
+	}
+}
+
+// 시청 중인 리플레이 변경
+// 관리자 API가 다른 고루틴에서 읽으므로 s.mutex Lock 상태에서 변경
+// run 고루틴에서 호출
+func (s *Server) setClientReplay(client *Client, player *replayPlayer) {
+	s.mutex.Lock()
+	client.replay = player
+	s.mutex.Unlock()
+}
@@ -2,7 +2,7 @@ package backend
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"sync"
 	"time"
 )
@@ -29,15 +29,20 @@ type Room struct {
 	maxPlayers     int
 	state          RoomState
 	game           *Game
+	settings       RoomSettings
+	botCounter     int
 	mutex          sync.RWMutex
 	loadingClients map[string]bool
+	logger         *slog.Logger // room_id 포함
 
 	// 채널
 	register      chan *Client  // 방에 참여하는 클라이언트
 	unregister    chan *Client  // 방에서 나가는 클라이언트
 	clientMessage chan *Message // 방 내부 클라이언트로부터 오는 메세지
 	broadcast     chan []byte   // 방 전체에 브로드캐스트
-	stop          chan struct{} // 방 고루틴을 중지
+	stop          chan string   // 방 고루틴을 중지 (종료 사유)
+	done          chan struct{} // 방 고루틴 종료 시 닫힘
+	closeReason   string        // 강제 종료 사유, run 고루틴에서만 접근
 }
 
 // 방 생성
@@ -62,9 +67,11 @@ func NewRoom(id string, owner *Client, server *Server, maxPlayers int) *Room {
 		register:       make(chan *Client, 1),
 		unregister:     make(chan *Client, 1),
 		clientMessage:  make(chan *Message, 1),
-		broadcast:      make(chan []byte, 256),
-		stop:           make(chan struct{}, 1),
+		broadcast:      make(chan []byte, server.config.Limits.RoomBroadcastBuffer),
+		stop:           make(chan string, 1),
+		done:           make(chan struct{}),
 		loadingClients: make(map[string]bool),
+		logger:         server.logger.With("room_id", id),
 	}
 
 	// 방장 추가
@@ -75,15 +82,17 @@ func NewRoom(id string, owner *Client, server *Server, maxPlayers int) *Room {
 
 	// 방 루프 실행
 	go room.run()
-	log.Printf("Room %s created by %s (Nick: %s). Max players: %d", room.id, owner.id, owner.nickname, room.maxPlayers)
+	room.logger.Debug("Room event loop launched", "owner_id", owner.id, "max_players", room.maxPlayers)
 	return room
 }
 
 // Room 메인 루프
 func (r *Room) run() {
-	log.Printf("Room %s event loop started.", r.id)
+	r.logger.Debug("Room event loop started")
 	defer func() {
-		log.Printf("Room %s event loop stopped.", r.id)
+		r.logger.Debug("Room event loop stopped")
+		// 종료된 방으로 보내는 채널 전송이 막히지 않도록 먼저 닫음
+		close(r.done)
 		r.cleanupRoomResources()
 		// 서버에 방 제거 알림
 		r.server.removeRoom <- r.id
@@ -98,7 +107,7 @@ func (r *Room) run() {
 			r.handleClientUnregister(client)
 			if len(r.clients) == 0 && r.state != RoomStatePlaying {
 				// 게임 중이 아닐 때 모든 플레이어가 나가면 방 제거
-				log.Printf("Room %s is empty and not in game, closing.", r.id)
+				r.logger.Info("Room is empty and not in game, closing")
 				return
 			}
 
@@ -108,7 +117,9 @@ func (r *Room) run() {
 		case messageBytes := <-r.broadcast:
 			r.broadcastToClients(messageBytes)
 
-		case <-r.stop:
+		case reason := <-r.stop:
+			r.closeReason = reason
+			r.logger.Info("Room closed", "reason", reason)
 			return
 		}
 	}
@@ -119,21 +130,37 @@ func (r *Room) handleClientRegister(client *Client) {
 
 	if len(r.clients) >= r.maxPlayers {
 		// 방이 꽉 찼을 시
-		log.Printf("Room %s is full. Cannot register client %s (Nick: %s).", r.id, client.id, client.nickname)
-		errorMsg := Message{Type: MessageTypeError, Payload: ErrorPayload{Message: "Room is full."}}
-		payloadBytes, _ := json.Marshal(errorMsg)
-		client.send <- payloadBytes
+		r.mutex.Unlock()
+		r.logger.Info("Room is full, join denied", "client_id", client.id)
+		r.sendError(client, "Room is full.")
+		return
+	}
+
+	// 방 안에서 닉네임 중복 불가
+	// 서버 고루틴의 프로필 변경과 겹치지 않도록 확인과 client.room 설정을 profileMutex 안에서 처리
+	client.profileMutex.Lock()
+	if r.isNicknameTaken(client.nickname, client) {
+		client.profileMutex.Unlock()
+		r.mutex.Unlock()
+		r.logger.Info("Nickname taken, join denied", "client_id", client.id, "nickname", client.nickname)
+		r.server.sendCodedError(client, ErrorCodeNicknameTaken, "방에 같은 닉네임을 쓰는 플레이어가 있습니다. 닉네임을 바꾼 뒤 참가해주세요.")
 		return
 	}
 
 	r.clients[client] = true
 	client.room = r // Client 객체에 Room 정보 설정
+	client.profileMutex.Unlock()
 	client.isReady = false
 	client.isOwner = (client == r.owner) // 방장 여부 확인
+	client.team = ""
+	if r.settings.TeamMode {
+		// 팀 모드면 인원이 적은 팀에 배정
+		client.team = r.smallestTeam()
+	}
 
 	r.mutex.Unlock()
 
-	log.Printf("Client %s (Nick: %s) registered to room %s. Current players: %d/%d", client.id, client.nickname, r.id, len(r.clients), r.maxPlayers)
+	r.logger.Info("Client joined room", "client_id", client.id, "nickname", client.nickname, "players", len(r.clients), "max_players", r.maxPlayers)
 
 	// 새 클라이언트에게 방 정보 전송
 	r.sendRoomInfoToClient(client)
@@ -155,7 +182,12 @@ func (r *Room) handleClientUnregister(client *Client) {
 
 	wasOwner := (client == r.owner)
 	delete(r.clients, client)
-	log.Printf("Client %s (Nick: %s) unregistered from room %s. Remaining players: %d", client.id, client.nickname, r.id, len(r.clients))
+	r.logger.Info("Client left room", "client_id", client.id, "nickname", client.nickname, "players", len(r.clients))
+
+	// 사람 플레이어가 모두 나가면 봇도 제거
+	if r.humanCount() == 0 {
+		r.removeAllBotsLocked()
+	}
 
 	// 클라이언트가 방을 나갔음을 다른 클라이언트에게 알림
 	playerLeftPayload := PlayerLeftPayload{PlayerID: client.id}
@@ -163,7 +195,7 @@ func (r *Room) handleClientUnregister(client *Client) {
 	if len(r.clients) == 0 {
 		if r.state == RoomStatePlaying && r.game != nil {
 			// 게임 중에 모든 플레이어가 나가면 (마지막 플레이어가 나감) 게임 즉시 종료 시도
-			log.Printf("Last player %s left room %s during game. Attempting to end game.", client.id, r.id)
+			r.logger.Info("Last player left during game, ending game", "client_id", client.id)
 			r.mutex.Unlock() // Lock 해제 후 게임 종료 함수 호출
 			r.game.StopGame("owner_left_or_all_left")
 			return
@@ -180,18 +212,21 @@ func (r *Room) handleClientUnregister(client *Client) {
 		var newOwner *Client
 		// 남아있는 클라이언트 중 한 명을 새 방장으로
 		for c := range r.clients {
+			if c.isBot {
+				continue
+			}
 			newOwner = c
 			break
 		}
 		if newOwner != nil {
 			r.owner = newOwner
 			newOwner.isOwner = true
-			log.Printf("New owner of room %s is %s (Nick: %s)", r.id, newOwner.id, newOwner.nickname)
+			r.logger.Info("Owner changed", "client_id", newOwner.id, "nickname", newOwner.nickname)
 			playerLeftPayload.NewOwnerID = newOwner.id
 			// 새 방장에게 방장 알림
 		} else {
 			// 모든 클라이언트가 나간 경우
-			log.Printf("Room %s: Owner left, but no other clients to assign ownership.", r.id)
+			r.logger.Debug("Owner left, no other clients to assign ownership")
 		}
 	}
 	r.mutex.Unlock() // Lock 해제 후 브로드캐스트
@@ -206,12 +241,14 @@ func (r *Room) handleClientUnregister(client *Client) {
 func (r *Room) handleClientMessage(msg *Message) {
 	// 방에 없는 클라이언트 메세지 처리
 	if _, ok := r.clients[msg.Sender]; !ok && msg.Type != MessageTypePlayerAction {
-		log.Printf("Room %s: Received message type %s from client %s (Nick: %s) not in this room. Ignored.",
-			r.id, msg.Type, msg.Sender.id, msg.Sender.nickname)
+		r.logger.Warn("Message from client not in this room ignored", "client_id", msg.Sender.id, "type", msg.Type)
 		return
 	}
 
-	log.Printf("Room %s received message from %s (Nick: %s): Type %s", r.id, msg.Sender.id, msg.Sender.nickname, msg.Type)
+	// player_action은 매 틱 들어오므로 샘플링
+	if msg.Type != MessageTypePlayerAction || playerActionLogSampler.Sample() {
+		r.logger.Debug("Room received message", "client_id", msg.Sender.id, "type", msg.Type)
+	}
 
 	switch msg.Type {
 	case MessageTypeReadyToggle:
@@ -220,12 +257,27 @@ func (r *Room) handleClientMessage(msg *Message) {
 	case MessageTypeStartGame:
 		// 게임 시작 처리
 		r.handleStartGameRequest(msg.Sender)
+	case MessageTypeUpdateRoomSettings:
+		// 방 설정 변경
+		r.handleUpdateRoomSettings(msg)
+	case MessageTypeSetTeam:
+		// 팀 선택
+		r.handleSetTeam(msg)
+	case MessageTypeAutoBalanceTeams:
+		// 팀 자동 분배
+		r.handleAutoBalanceTeams(msg.Sender)
+	case MessageTypeAddBot:
+		// 봇 추가
+		r.handleAddBot(msg)
+	case MessageTypeRemoveBot:
+		// 봇 제거
+		r.handleRemoveBot(msg)
 	case MessageTypePlayerAction:
 		// 게임 진행중일 때 플레이어 액션 처리
 		if r.state == RoomStatePlaying && r.game != nil {
 			r.game.HandlePlayerAction(msg)
 		} else {
-			log.Printf("Room %s: PlayerAction from %s (Nick: %s) received but game is not running or nil.", r.id, msg.Sender.id, msg.Sender.nickname)
+			r.logger.Debug("Player action received but game is not running", "client_id", msg.Sender.id)
 		}
 	case MessageTypeLeaveRoom:
 		// 방 나가기 처리
@@ -234,6 +286,7 @@ func (r *Room) handleClientMessage(msg *Message) {
 		client.room = nil
 		client.isOwner = false
 		client.isReady = false
+		client.team = ""
 
 		r.mutex.Unlock()
 		// 이 부분 채널 버퍼 처리 안 하면 막힘
@@ -241,7 +294,7 @@ func (r *Room) handleClientMessage(msg *Message) {
 	case MessageTypeGameLoadingComplete:
 		r.handleGameLoadingComplete(msg.Sender)
 	default:
-		log.Printf("Room %s: Received unhandled message type %s from %s (Nick: %s)", r.id, msg.Type, msg.Sender.id, msg.Sender.nickname)
+		r.logger.Warn("Unhandled room message", "client_id", msg.Sender.id, "type", msg.Type)
 	}
 }
 
@@ -249,12 +302,12 @@ func (r *Room) handleClientMessage(msg *Message) {
 func (r *Room) handleReadyToggle(client *Client) {
 	r.mutex.Lock()
 	if r.state != RoomStateWaiting && r.state != RoomStateFinished {
-		log.Printf("Room %s: Client %s (Nick: %s) tried to toggle ready but room state is %s.", r.id, client.id, client.nickname, r.state)
+		r.logger.Debug("Ready toggle denied", "client_id", client.id, "state", r.state)
 		r.mutex.Unlock()
 		return
 	}
 	client.isReady = !client.isReady
-	log.Printf("Room %s: Client %s (Nick: %s) ready status: %t", r.id, client.id, client.nickname, client.isReady)
+	r.logger.Info("Ready status changed", "client_id", client.id, "ready", client.isReady)
 	r.mutex.Unlock()
 
 	payload := PlayerReadyChangedPayload{
@@ -269,7 +322,7 @@ func (r *Room) handleReadyToggle(client *Client) {
 func (r *Room) handleStartGameRequest(client *Client) {
 	r.mutex.Lock()
 	if client != r.owner {
-		log.Printf("Room %s: Start game request from non-owner %s (Nick: %s). Denied.", r.id, client.id, client.nickname)
+		r.logger.Warn("Start game denied: not owner", "client_id", client.id)
 		r.mutex.Unlock()
 		return
 	}
@@ -282,29 +335,45 @@ func (r *Room) handleStartGameRequest(client *Client) {
 		return
 	}
 	if r.state != RoomStateWaiting {
-		log.Printf("Room %s: Start game request while room state is %s. Denied.", r.id, r.state)
+		r.logger.Debug("Start game denied", "state", r.state)
 		r.mutex.Unlock()
 		return
 	}
+	if r.server.IsShuttingDown() {
+		r.mutex.Unlock()
+		r.sendError(client, "서버가 곧 종료되어 게임을 시작할 수 없습니다.")
+		return
+	}
+	if r.server.IsMaintenance() {
+		r.mutex.Unlock()
+		r.sendError(client, "서버 점검 중에는 새 게임을 시작할 수 없습니다.")
+		return
+	}
 
 	canStart := true
 	errorMsg := ""
 	if len(r.clients) < 1 {
 		canStart = false
 		errorMsg = "플레이어 수가 부족합니다."
-		log.Printf("Room %s: Not enough players to start. Current: %d", r.id, len(r.clients))
+		r.logger.Debug("Not enough players to start", "players", len(r.clients))
 	} else {
 		for c := range r.clients {
 			// 방장은 레디 아니어도 가능
 			if !c.isReady && c != r.owner {
 				canStart = false
 				errorMsg = "모든 플레이어가 준비되지 않았습니다."
-				log.Printf("Room %s: Client %s (Nick: %s) is not ready. Cannot start game.", r.id, c.id, c.nickname)
+				r.logger.Debug("Player not ready, cannot start game", "client_id", c.id)
 				break
 			}
 		}
 	}
 
+	if canStart && r.settings.TeamMode {
+		// 팀 없는 플레이어 배정 후 팀 구성 확인
+		r.assignMissingTeams()
+		canStart, errorMsg = r.canStartTeamGame()
+	}
+
 	if !canStart {
 		errorMsg := Message{Type: MessageTypeError, Payload: ErrorPayload{Message: errorMsg}}
 		payloadBytes, _ := json.Marshal(errorMsg)
@@ -314,13 +383,14 @@ func (r *Room) handleStartGameRequest(client *Client) {
 		return
 	}
 
-	log.Printf("Room %s: All players ready. Owner %s (Nick: %s) started the game.", r.id, client.id, client.nickname)
+	r.logger.Info("Game started by owner", "client_id", client.id, "players", len(r.clients))
 	r.state = RoomStatePlaying
 
 	// 로딩 상태 초기화
+	// 봇은 로딩이 필요 없으므로 완료 처리
 	r.loadingClients = make(map[string]bool)
 	for c := range r.clients {
-		r.loadingClients[c.id] = false
+		r.loadingClients[c.id] = c.isBot
 	}
 
 	r.mutex.Unlock()
@@ -348,6 +418,38 @@ func (r *Room) handleStartGameRequest(client *Client) {
 	r.game.Ready()
 }
 
+// 방 고루틴 채널로 전송
+// 방이 이미 닫혔으면 false
+func sendToRoom[T any](r *Room, ch chan T, value T) bool {
+	select {
+	case ch <- value:
+		return true
+	case <-r.done:
+		return false
+	}
+}
+
+// 방 강제 종료
+// 진행 중인 게임은 종료되고 남은 플레이어는 로비로 이동
+func (r *Room) Close(reason string) {
+	select {
+	case r.stop <- reason:
+	default:
+	}
+}
+
+// 클라이언트에게 에러 메세지 전송
+func (r *Room) sendError(client *Client, message string) {
+	errorMsg := Message{Type: MessageTypeError, Payload: ErrorPayload{Message: message}}
+	payloadBytes, _ := json.Marshal(errorMsg)
+	select {
+	case client.send <- payloadBytes:
+	default:
+		metrics.droppedSends.Inc("room")
+		r.logger.Warn("Send channel full, error message not sent", "client_id", client.id)
+	}
+}
+
 // 방 Broadcast
 func (r *Room) broadcastToClients(messageBytes []byte) {
 	r.mutex.RLock()
@@ -355,7 +457,11 @@ func (r *Room) broadcastToClients(messageBytes []byte) {
 		select {
 		case client.send <- messageBytes:
 		default:
-			log.Printf("Room %s: Client %s (Nick: %s) send channel full or closed. Message not sent.", r.id, client.id, client.nickname)
+			metrics.droppedSends.Inc("room")
+			// 게임 상태 업데이트마다 발생할 수 있으므로 샘플링
+			if droppedSendLogSampler.Sample() {
+				r.logger.Warn("Send channel full, message not sent", "client_id", client.id)
+			}
 		}
 	}
 	r.mutex.RUnlock()
@@ -365,7 +471,8 @@ func (r *Room) broadcastToClients(messageBytes []byte) {
 func (r *Room) broadcastMessage(msg Message, exclude *Client) {
 	payloadBytes, err := json.Marshal(msg)
 	if err != nil {
-		log.Printf("Room %s: Error marshalling broadcast message type %s: %v", r.id, msg.Type, err)
+		metrics.marshalErrors.Add(1)
+		r.logger.Error("Failed to marshal broadcast message", "type", msg.Type, "error", err)
 		return
 	}
 
@@ -377,7 +484,8 @@ func (r *Room) broadcastMessage(msg Message, exclude *Client) {
 		select {
 		case client.send <- payloadBytes:
 		default:
-			log.Printf("Room %s: Client %s (Nick: %s) send channel full or closed for broadcast. Message type %s not sent.", r.id, client.id, client.nickname, msg.Type)
+			metrics.droppedSends.Inc("room")
+			r.logger.Warn("Send channel full, broadcast not sent", "client_id", client.id, "type", msg.Type)
 		}
 	}
 	r.mutex.RUnlock()
@@ -399,11 +507,13 @@ func (r *Room) sendRoomInfoToClient(client *Client) {
 		MaxPlayers:     r.maxPlayers,
 		State:          r.state,
 		CurrentPlayers: len(r.clients),
+		Settings:       r.settings,
 	}
 	msg := Message{Type: MessageTypeRoomJoined, Payload: roomInfoPayload}
 	payloadBytes, err := json.Marshal(msg)
 	if err != nil {
-		log.Printf("Room %s: Error marshalling room info for client %s: %v", r.id, client.id, err)
+		metrics.marshalErrors.Add(1)
+		r.logger.Error("Failed to marshal room info", "client_id", client.id, "error", err)
 		r.mutex.RUnlock()
 		return
 	}
@@ -414,12 +524,15 @@ func (r *Room) sendRoomInfoToClient(client *Client) {
 // Client 객체로 PlayerInfo 조회
 func (r *Room) getPlayerInfo(client *Client) PlayerInfo {
 	playerInfo := PlayerInfo{
-		ID:        client.id,
-		Nickname:  client.nickname,
-		Color:     client.color,
-		Character: client.character,
-		IsReady:   client.isReady,
-		IsOwner:   client.isOwner,
+		ID:            client.id,
+		Nickname:      client.nickname,
+		Color:         client.color,
+		Character:     client.character,
+		Team:          client.team,
+		IsReady:       client.isReady,
+		IsOwner:       client.isOwner,
+		IsBot:         client.isBot,
+		BotDifficulty: client.botDifficulty,
 	}
 
 	// 게임이 진행 중이고 PlayerState가 있으면 asset 정보 포함
@@ -445,30 +558,44 @@ func (r *Room) broadcastRoomState() {
 		RoomID:   r.id,
 		NewState: r.state,
 		Players:  playersInfo,
+		Settings: r.settings,
 	}
 	msg := Message{Type: MessageTypeRoomStateUpdated, Payload: payload}
 
 	r.mutex.RUnlock()
 
 	r.broadcastMessage(msg, nil)
-	log.Printf("Room %s: Broadcasted room state update. New state: %s", r.id, r.state)
+	r.logger.Debug("Broadcasted room state", "state", r.state)
 }
 
 // 방 제거 Clean up
 func (r *Room) cleanupRoomResources() {
-	log.Printf("Room %s: Cleaning up resources...", r.id)
+	r.logger.Debug("Cleaning up resources")
 	// 게임이 진행 중이었다면 게임 중지
 	if r.game != nil && r.game.isReady {
-		r.game.StopGame("room_closed")
+		reason := "room_closed"
+		if r.closeReason != "" {
+			reason = r.closeReason
+		}
+		r.game.StopGame(reason)
 	}
 	r.game = nil
 
+	// 강제 종료면 남은 플레이어에게 알림
+	if r.closeReason != "" {
+		r.broadcastMessage(Message{Type: MessageTypeRoomClosed, Payload: RoomClosedPayload{RoomID: r.id, Reason: r.closeReason}}, nil)
+	}
+
 	r.mutex.Lock()
 
 	for client := range r.clients {
+		if client.isBot {
+			close(client.send)
+		}
 		client.room = nil
 		client.isOwner = false
 		client.isReady = false
+		client.team = ""
 	}
 
 	r.clients = make(map[*Client]bool)
@@ -478,16 +605,34 @@ func (r *Room) cleanupRoomResources() {
 
 // 게임 종료 및 준비상태 초기화
 func (r *Room) SetGameEnded() {
+	// r.mutex를 잡은 상태에서 s.mutex를 잡지 않도록 연결 여부를 먼저 확인
+	r.mutex.RLock()
+	members := make([]*Client, 0, len(r.clients))
+	for client := range r.clients {
+		members = append(members, client)
+	}
+	r.mutex.RUnlock()
+	disconnected := make(map[*Client]bool)
+	for _, client := range members {
+		if !client.isBot && (client.conn == nil || !r.server.isClientConnected(client.id)) {
+			disconnected[client] = true
+		}
+	}
+
 	r.mutex.Lock()
 	r.state = RoomStateFinished
 	r.game = nil
 	r.loadingClients = make(map[string]bool)
 
-	log.Printf("Room %s: Preparing for new game. Resetting player ready states.", r.id)
+	r.logger.Debug("Preparing for new game, resetting ready states")
 	for client := range r.clients {
+		if client.isBot {
+			// 봇은 항상 준비 상태 유지
+			continue
+		}
 		client.isReady = false
-		if client.conn == nil || !r.server.isClientConnected(client.id) {
-			log.Printf("Room %s: Removing disconnected client %s (Nick: %s) while preparing for new game.", r.id, client.id, client.nickname)
+		if disconnected[client] {
+			r.logger.Info("Removing disconnected client after game", "client_id", client.id)
 			delete(r.clients, client)
 
 			defer r.server.broadcastRoomUpdate()
@@ -513,7 +658,7 @@ func (r *Room) PrepareForNewGame() {
 // 게임 초기 데이터 전송
 func (r *Room) sendGameInitData() {
 	if r.game == nil {
-		log.Printf("Room %s: Cannot send game init data, game is nil", r.id)
+		r.logger.Error("Cannot send game init data, game is nil")
 		return
 	}
 
@@ -530,6 +675,8 @@ func (r *Room) sendGameInitData() {
 			Pitch:     playerState.Pitch,
 			Score:     playerState.Score,
 			Asset:     playerState.Asset,
+			Team:      playerState.Team,
+			Character: playerState.Character,
 			Health:    playerState.Health,
 			MaxHealth: playerState.MaxHealth,
 			IsAlive:   playerState.IsAlive,
@@ -538,13 +685,14 @@ func (r *Room) sendGameInitData() {
 
 	// 게임 init data
 	initPayload := GameInitDataPayload{
-		Players: playerStates,
-		// MapData와 GameConfig 추후 추가
+		Players:    playerStates,
+		GameConfig: r.game.balance,
+		// MapData 추후 추가
 	}
 
 	msg := Message{Type: MessageTypeGameInitData, Payload: initPayload}
-	r.broadcastMessage(msg, nil)
-	log.Printf("Room %s: Sent game initialization data to all clients", r.id)
+	r.game.broadcastAndRecord(msg)
+	r.logger.Debug("Sent game init data")
 }
 
 // 게임 로딩 완료 처리
@@ -556,7 +704,7 @@ func (r *Room) handleGameLoadingComplete(client *Client) {
 	// 로딩 완료 상태 업데이트
 	if _, exists := r.loadingClients[client.id]; exists {
 		r.loadingClients[client.id] = true
-		log.Printf("Room %s: Client %s (Nick: %s) completed game loading", r.id, client.id, client.nickname)
+		r.logger.Debug("Client completed game loading", "client_id", client.id)
 	}
 
 	// 모든 클라이언트가 로딩을 완료했는지 확인
@@ -572,7 +720,7 @@ func (r *Room) handleGameLoadingComplete(client *Client) {
 
 	// 모든 클라이언트 로딩 완료시 게임 시작
 	if allLoaded {
-		log.Printf("Room %s: All clients completed loading, starting game countdown", r.id)
+		r.logger.Info("All clients loaded, starting countdown")
 		if r.game != nil {
 			go r.game.Start() // 게임 메인 루프 시작
 		}
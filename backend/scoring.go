@@ -0,0 +1,122 @@
+package backend
+
+import "time"
+
+const (
+	maxScoringValue = 5 // 점수 규칙 값 최대
+
+	// 추가 점수 종류 (kill 이벤트 표시용)
+	scoreBonusStreak  = "streak"
+	scoreBonusBounty  = "bounty"
+	scoreBonusRevenge = "revenge"
+)
+
+// 점수 규칙
+// 방마다 설정, 모두 0이면 처치당 1점
+type ScoringRules struct {
+	StreakBonus  int `json:"streak_bonus"`  // streakEventMin 이상 연속 처치 중일 때 처치당 추가 점수
+	LeaderBounty int `json:"leader_bounty"` // 현재 1위 (동점 포함, 1점 이상) 처치 시 추가 점수
+	RevengeBonus int `json:"revenge_bonus"` // 마지막으로 나를 처치한 플레이어 처치 시 추가 점수
+	DeathPenalty int `json:"death_penalty"` // 죽을 때 잃는 점수 (0점 아래로는 내려가지 않음)
+}
+
+// 점수 규칙 변경 요청
+// 비어있는 값은 유지
+type ScoringRulesPayload struct {
+	StreakBonus  *int `json:"streak_bonus,omitempty"`
+	LeaderBounty *int `json:"leader_bounty,omitempty"`
+	RevengeBonus *int `json:"revenge_bonus,omitempty"`
+	DeathPenalty *int `json:"death_penalty,omitempty"`
+}
+
+// 점수 규칙 값 범위 확인
+func (p *ScoringRulesPayload) isValid() bool {
+	for _, value := range []*int{p.StreakBonus, p.LeaderBounty, p.RevengeBonus, p.DeathPenalty} {
+		if value != nil && (*value < 0 || *value > maxScoringValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// 점수 규칙 변경 적용
+func (rules *ScoringRules) apply(p *ScoringRulesPayload) {
+	if p.StreakBonus != nil {
+		rules.StreakBonus = *p.StreakBonus
+	}
+	if p.LeaderBounty != nil {
+		rules.LeaderBounty = *p.LeaderBounty
+	}
+	if p.RevengeBonus != nil {
+		rules.RevengeBonus = *p.RevengeBonus
+	}
+	if p.DeathPenalty != nil {
+		rules.DeathPenalty = *p.DeathPenalty
+	}
+}
+
+// 처치 점수 결과
+type killScore struct {
+	points     int      // 처치한 플레이어가 얻은 점수
+	bonuses    []string // 적용된 추가 점수 종류
+	pointsLost int      // 죽은 플레이어가 잃은 점수
+}
+
+// 처치 점수 계산 및 적용
+// attacker가 nil이거나 아군 처치면 처치 점수 없이 죽은 플레이어 감점만 적용
+// g.mutex Lock 상태에서 호출
+func (g *Game) scoreKill(attacker, victim *PlayerState, friendly bool, now time.Time) killScore {
+	rules := g.settings.Scoring
+	var result killScore
+
+	if attacker != nil && !friendly {
+		// 1위 여부는 점수 변경 전에 판단
+		isLeader := g.isLeader(victim)
+
+		attacker.Combat.addKill()
+		result.points = 1
+
+		if rules.StreakBonus > 0 && attacker.Combat.currentStreak >= streakEventMin {
+			result.points += rules.StreakBonus
+			result.bonuses = append(result.bonuses, scoreBonusStreak)
+		}
+		if rules.LeaderBounty > 0 && isLeader {
+			result.points += rules.LeaderBounty
+			result.bonuses = append(result.bonuses, scoreBonusBounty)
+			attacker.Combat.Bounties++
+		}
+		if rules.RevengeBonus > 0 && attacker.lastKillerID == victim.ID {
+			result.points += rules.RevengeBonus
+			result.bonuses = append(result.bonuses, scoreBonusRevenge)
+			attacker.Combat.Revenges++
+		}
+		// 복수는 한 번만
+		if attacker.lastKillerID == victim.ID {
+			attacker.lastKillerID = ""
+		}
+
+		attacker.Score += result.points
+		attacker.Combat.BonusPoints += result.points - 1
+		victim.lastKillerID = attacker.ID
+	}
+
+	if rules.DeathPenalty > 0 {
+		result.pointsLost = min(rules.DeathPenalty, victim.Score)
+		victim.Score -= result.pointsLost
+		victim.Combat.PointsLost += result.pointsLost
+	}
+	return result
+}
+
+// 현재 1위인지 (동점 포함, 0점이면 아님)
+func (g *Game) isLeader(ps *PlayerState) bool {
+	if ps.Score <= 0 {
+		return false
+	}
+	for _, other := range g.players {
+		if other.Score > ps.Score {
+			return false
+		}
+	}
+	return true
+}
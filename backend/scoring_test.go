@@ -0,0 +1,292 @@
+package backend
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScoreKill(t *testing.T) {
+	allRules := ScoringRules{StreakBonus: 2, LeaderBounty: 3, RevengeBonus: 1, DeathPenalty: 2}
+
+	tests := []struct {
+		name     string
+		rules    ScoringRules
+		setup    func(attacker, victim, other *PlayerState)
+		noKiller bool // 환경에 의한 죽음
+		friendly bool
+		want     killScore
+		// 적용 후 점수
+		attackerScore int
+		victimScore   int
+	}{
+		{
+			name:          "default rules",
+			setup:         func(a, v, o *PlayerState) { v.Score = 4 },
+			want:          killScore{points: 1},
+			attackerScore: 1, victimScore: 4,
+		},
+		{
+			name:          "streak bonus below threshold",
+			rules:         ScoringRules{StreakBonus: 2},
+			setup:         func(a, v, o *PlayerState) { a.Combat.currentStreak = streakEventMin - 2 },
+			want:          killScore{points: 1},
+			attackerScore: 1,
+		},
+		{
+			name:          "streak bonus at threshold",
+			rules:         ScoringRules{StreakBonus: 2},
+			setup:         func(a, v, o *PlayerState) { a.Combat.currentStreak = streakEventMin - 1 },
+			want:          killScore{points: 3, bonuses: []string{scoreBonusStreak}},
+			attackerScore: 3,
+		},
+		{
+			name:          "leader bounty",
+			rules:         ScoringRules{LeaderBounty: 3},
+			setup:         func(a, v, o *PlayerState) { v.Score = 5; o.Score = 2 },
+			want:          killScore{points: 4, bonuses: []string{scoreBonusBounty}},
+			attackerScore: 4, victimScore: 5,
+		},
+		{
+			name:          "leader bounty on tied leader",
+			rules:         ScoringRules{LeaderBounty: 3},
+			setup:         func(a, v, o *PlayerState) { v.Score = 2; o.Score = 2 },
+			want:          killScore{points: 4, bonuses: []string{scoreBonusBounty}},
+			attackerScore: 4, victimScore: 2,
+		},
+		{
+			name:          "no bounty on non-leader",
+			rules:         ScoringRules{LeaderBounty: 3},
+			setup:         func(a, v, o *PlayerState) { v.Score = 1; o.Score = 2 },
+			want:          killScore{points: 1},
+			attackerScore: 1, victimScore: 1,
+		},
+		{
+			name:          "no bounty at zero points",
+			rules:         ScoringRules{LeaderBounty: 3},
+			want:          killScore{points: 1},
+			attackerScore: 1,
+		},
+		{
+			name:          "revenge bonus",
+			rules:         ScoringRules{RevengeBonus: 1},
+			setup:         func(a, v, o *PlayerState) { a.lastKillerID = v.ID },
+			want:          killScore{points: 2, bonuses: []string{scoreBonusRevenge}},
+			attackerScore: 2,
+		},
+		{
+			name:          "death penalty",
+			rules:         ScoringRules{DeathPenalty: 2},
+			setup:         func(a, v, o *PlayerState) { v.Score = 5 },
+			want:          killScore{points: 1, pointsLost: 2},
+			attackerScore: 1, victimScore: 3,
+		},
+		{
+			name:          "death penalty stops at zero",
+			rules:         ScoringRules{DeathPenalty: 2},
+			setup:         func(a, v, o *PlayerState) { v.Score = 1 },
+			want:          killScore{points: 1, pointsLost: 1},
+			attackerScore: 1, victimScore: 0,
+		},
+		{
+			name:          "environment kill only applies penalty",
+			rules:         allRules,
+			setup:         func(a, v, o *PlayerState) { v.Score = 5 },
+			noKiller:      true,
+			want:          killScore{pointsLost: 2},
+			attackerScore: 0, victimScore: 3,
+		},
+		{
+			name:          "friendly kill only applies penalty",
+			rules:         allRules,
+			setup:         func(a, v, o *PlayerState) { v.Score = 5; a.lastKillerID = v.ID },
+			friendly:      true,
+			want:          killScore{pointsLost: 2},
+			attackerScore: 0, victimScore: 3,
+		},
+		{
+			name:  "all rules",
+			rules: allRules,
+			setup: func(a, v, o *PlayerState) {
+				a.Combat.currentStreak = streakEventMin - 1
+				a.lastKillerID = v.ID
+				v.Score = 6
+			},
+			want:          killScore{points: 7, bonuses: []string{scoreBonusStreak, scoreBonusBounty, scoreBonusRevenge}, pointsLost: 2},
+			attackerScore: 7, victimScore: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attacker := newTestPlayer("attacker", 0, 0, 3)
+			victim := newTestPlayer("victim", 1, 0, 3)
+			other := newTestPlayer("other", 2, 0, 3)
+			g := newTestGame(time.Unix(1000, 0), attacker, victim, other)
+			g.settings.Scoring = tt.rules
+			if tt.setup != nil {
+				tt.setup(attacker, victim, other)
+			}
+
+			killer := attacker
+			if tt.noKiller {
+				killer = nil
+			}
+			got := g.scoreKill(killer, victim, tt.friendly, time.Unix(1000, 0))
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("scoreKill = %+v, want %+v", got, tt.want)
+			}
+			if attacker.Score != tt.attackerScore || victim.Score != tt.victimScore {
+				t.Fatalf("scores attacker=%d victim=%d, want %d, %d", attacker.Score, victim.Score, tt.attackerScore, tt.victimScore)
+			}
+			if attacker.Combat.BonusPoints != max(0, tt.want.points-1) {
+				t.Fatalf("bonus points = %d, want %d", attacker.Combat.BonusPoints, max(0, tt.want.points-1))
+			}
+			if victim.Combat.PointsLost != tt.want.pointsLost {
+				t.Fatalf("points lost = %d, want %d", victim.Combat.PointsLost, tt.want.pointsLost)
+			}
+		})
+	}
+}
+
+func TestScoreKillRevengeOnlyOnce(t *testing.T) {
+	a := newTestPlayer("a", 0, 0, 3)
+	b := newTestPlayer("b", 1, 0, 3)
+	g := newTestGame(time.Unix(1000, 0), a, b)
+	g.settings.Scoring = ScoringRules{RevengeBonus: 2}
+	now := time.Unix(1000, 0)
+
+	// b가 a를 처치 -> a가 b에게 복수 -> 다시 처치하면 복수 점수 없음
+	g.scoreKill(b, a, false, now)
+	if got := g.scoreKill(a, b, false, now); got.points != 3 {
+		t.Fatalf("revenge kill points = %d, want 3", got.points)
+	}
+	if got := g.scoreKill(a, b, false, now); got.points != 1 {
+		t.Fatalf("second kill points = %d, want 1", got.points)
+	}
+	if a.Combat.Revenges != 1 {
+		t.Fatalf("revenges = %d, want 1", a.Combat.Revenges)
+	}
+}
+
+func TestKillEventsCarryPointsAndStreak(t *testing.T) {
+	now := time.Unix(1000, 0)
+	attacker := newTestPlayer("attacker", 0, 0, 3)
+	g := newTestGame(now, attacker)
+	g.settings.Scoring = ScoringRules{StreakBonus: 1}
+
+	// streakEventMin명을 연속 처치
+	for i := 0; i < streakEventMin; i++ {
+		victim := newTestPlayer("victim", 1, 0, 1)
+		g.players[&Client{id: victim.ID}] = victim
+		if !g.damagePlayer(attacker, victim, damageInfo{amount: 1, source: damageSourceHammer}, now) {
+			t.Fatalf("kill %d not applied", i+1)
+		}
+	}
+
+	var kills, streaks []GameEvent
+	for _, event := range g.events {
+		switch event.Type {
+		case GameEventKill:
+			kills = append(kills, event)
+		case GameEventStreak:
+			streaks = append(streaks, event)
+		}
+	}
+	if len(kills) != streakEventMin {
+		t.Fatalf("kill events = %d, want %d", len(kills), streakEventMin)
+	}
+	last := kills[len(kills)-1]
+	if last.Points != 2 || !reflect.DeepEqual(last.Bonuses, []string{scoreBonusStreak}) {
+		t.Fatalf("last kill event points=%d bonuses=%v, want 2 [streak]", last.Points, last.Bonuses)
+	}
+	if len(streaks) != 1 || streaks[0].Streak != streakEventMin || streaks[0].PlayerID != attacker.ID {
+		t.Fatalf("streak events = %+v, want one streak of %d", streaks, streakEventMin)
+	}
+	if want := streakEventMin + 1; attacker.Score != want {
+		t.Fatalf("attacker score = %d, want %d", attacker.Score, want)
+	}
+}
+
+func TestTeamScoring(t *testing.T) {
+	player := func(id, team string, score int) *PlayerState {
+		ps := newTestPlayer(id, 0, 0, 3)
+		ps.Team = team
+		ps.Score = score
+		return ps
+	}
+
+	tests := []struct {
+		name     string
+		teamMode bool
+		players  []*PlayerState
+		want     map[string]int
+		winner   string
+	}{
+		{
+			name:    "free for all has no team scores",
+			players: []*PlayerState{player("a", TeamRed, 3)},
+			want:    nil,
+		},
+		{
+			name:     "sums members",
+			teamMode: true,
+			players:  []*PlayerState{player("a", TeamRed, 3), player("b", TeamRed, 2), player("c", TeamBlue, 4)},
+			want:     map[string]int{TeamRed: 5, TeamBlue: 4},
+			winner:   TeamRed,
+		},
+		{
+			name:     "tie has no winner",
+			teamMode: true,
+			players:  []*PlayerState{player("a", TeamRed, 3), player("b", TeamBlue, 3)},
+			want:     map[string]int{TeamRed: 3, TeamBlue: 3},
+			winner:   "",
+		},
+		{
+			name:     "empty team scores zero",
+			teamMode: true,
+			players:  []*PlayerState{player("a", TeamBlue, 1), player("b", "", 9)},
+			want:     map[string]int{TeamRed: 0, TeamBlue: 1},
+			winner:   TeamBlue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(time.Unix(1000, 0), tt.players...)
+			g.settings.TeamMode = tt.teamMode
+
+			scores := g.teamScores()
+			if !reflect.DeepEqual(scores, tt.want) {
+				t.Fatalf("teamScores = %v, want %v", scores, tt.want)
+			}
+			if scores != nil {
+				if got := winningTeam(scores); got != tt.winner {
+					t.Fatalf("winningTeam = %q, want %q", got, tt.winner)
+				}
+			}
+		})
+	}
+}
+
+func TestFriendlyFireInTeamMode(t *testing.T) {
+	now := time.Unix(1000, 0)
+	attacker := newTestPlayer("attacker", 0, 0, 3)
+	teammate := newTestPlayer("teammate", 1, 0, 1)
+	attacker.Team, teammate.Team = TeamRed, TeamRed
+	g := newTestGame(now, attacker, teammate)
+	g.settings.TeamMode = true
+
+	if g.damagePlayer(attacker, teammate, damageInfo{amount: 1}, now) {
+		t.Fatal("teammate damaged with friendly fire off")
+	}
+
+	g.settings.FriendlyFire = true
+	if !g.damagePlayer(attacker, teammate, damageInfo{amount: 1}, now) {
+		t.Fatal("teammate not damaged with friendly fire on")
+	}
+	if teammate.IsAlive || attacker.Score != 0 || attacker.Combat.Kills != 0 {
+		t.Fatalf("friendly kill: alive=%v score=%d kills=%d, want dead, 0, 0", teammate.IsAlive, attacker.Score, attacker.Combat.Kills)
+	}
+}
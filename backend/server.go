@@ -2,34 +2,63 @@ package backend
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gorilla/websocket"
 )
 
 type Server struct {
-	clients      map[string]*Client
-	rooms        map[string]*Room
-	nextClientID int64
-	mutex        sync.RWMutex
+	clients map[string]*Client
+	rooms   map[string]*Room
+	// IP별 연결 수 (업그레이드 전 예약 포함)
+	connectionsByIP map[string]int
+	nextClientID    int64
+	mutex           sync.RWMutex
+	logger          *slog.Logger
+	config          Config
+	upgrader        websocket.Upgrader
+	replays         *ReplayStore  // nil이면 리플레이 녹화 비활성화
+	balance         *BalanceStore // nil이면 기본 밸런스 고정
+	shuttingDown    atomic.Bool   // 종료 중이면 새 연결, 방 생성/참가 거부
+	maintenance     atomic.Bool   // 점검 중이면 방 생성, 방 참가, 게임 시작 거부
+
+	maintenanceNotice string // 점검 안내 문구 (비어있으면 기본 문구)
+
+	// 멀티 노드 방 디렉터리 (nil이면 단일 노드)
+	directory      RoomDirectory
+	nodeID         string
+	nodeURL        string
+	remoteRoomIDs  map[string]bool // 다른 노드의 방 목록 캐시 (디렉터리 고루틴이 갱신)
+	removedRoomIDs []string        // 디렉터리에서 제거할 방 (디렉터리 고루틴이 처리)
+	remoteRoomList []RoomListItem
+	directorySync  chan struct{} // 로컬 방 정보 변경 알림 (디렉터리 고루틴이 처리)
 
 	// 채널
-	register           chan *Client  // 새로운 클라이언트 등록
-	unregister         chan *Client  // 클라이언트 등록 해제
-	createRoom         chan *Client  // 방 생성 요청
-	joinRoom           chan *Message // 방 참가 요청
-	listRooms          chan *Client  // 방 목록 요청
-	removeRoom         chan string   // 방 제거
-	routeClientMessage chan *Message // 서버 처리 채널
+	register           chan *Client           // 새로운 클라이언트 등록
+	unregister         chan *Client           // 클라이언트 등록 해제
+	createRoom         chan *Client           // 방 생성 요청
+	joinRoom           chan *Message          // 방 참가 요청
+	listRooms          chan *Client           // 방 목록 요청
+	removeRoom         chan string            // 방 제거
+	routeClientMessage chan *Message          // 서버 처리 채널
+	replayLoaded       chan *replayLoadResult // 리플레이 로드 완료
 }
 
 // 서버 인스턴스 생성
-func NewServer() *Server {
+// cfg는 Validate를 통과한 설정, logger가 nil이면 slog 기본 로거 사용
+func NewServer(cfg Config, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	s := &Server{
+		logger:             logger,
+		config:             cfg,
 		clients:            make(map[string]*Client, 1),
 		rooms:              make(map[string]*Room, 1),
+		connectionsByIP:    make(map[string]int),
 		nextClientID:       1,
 		register:           make(chan *Client, 1),
 		unregister:         make(chan *Client, 1),
@@ -38,11 +67,26 @@ func NewServer() *Server {
 		listRooms:          make(chan *Client, 1),
 		removeRoom:         make(chan string, 1),
 		routeClientMessage: make(chan *Message, 256),
+		replayLoaded:       make(chan *replayLoadResult, 8),
+		directorySync:      make(chan struct{}, 1),
+	}
+	s.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			return cfg.isOriginAllowed(r.Header.Get("Origin"), r.Host)
+		},
 	}
 	go s.run()
 	return s
 }
 
+// 리플레이 저장소 설정
+// 게임 시작 전에 호출해야 녹화됨
+func (s *Server) SetReplayStore(store *ReplayStore) {
+	s.replays = store
+}
+
 // 서버 메인 루프
 func (s *Server) run() {
 	for {
@@ -68,6 +112,9 @@ func (s *Server) run() {
 		case msg := <-s.routeClientMessage:
 			// Client.readPump에서 라우팅된 메시지
 			s.handleRoutedMessage(msg)
+		case result := <-s.replayLoaded:
+			// 리플레이 로드 완료
+			s.handleReplayLoaded(result)
 		}
 	}
 }
@@ -77,10 +124,13 @@ func (s *Server) handleClientRegister(client *Client) {
 	s.mutex.Lock()
 	s.clients[client.id] = client
 	s.mutex.Unlock()
-	log.Printf("Server: Client %s (Nick: %s) registered. Total clients: %d", client.id, client.nickname, len(s.clients))
+	client.logger.Info("Client registered", "nickname", client.nickname, "total_clients", len(s.clients))
 
 	// 방 리스트 전송
 	s.sendRoomListToClient(client)
+
+	// 점검 중이면 안내
+	s.sendMaintenanceNotice(client)
 }
 
 // 클라이언트 해제 처리
@@ -89,7 +139,12 @@ func (s *Server) handleClientUnregister(client *Client) {
 	// Room의 unregister 로직 처리 후
 	if _, ok := s.clients[client.id]; ok {
 		delete(s.clients, client.id)
-		log.Printf("Server: Client %s (Nick: %s) unregistered. Total clients: %d", client.id, client.nickname, len(s.clients))
+		client.logger.Info("Client unregistered", "nickname", client.nickname, "total_clients", len(s.clients))
+		// 시청 중인 리플레이 중지
+		if client.replay != nil {
+			client.replay.Stop()
+			client.replay = nil
+		}
 		// 마지막 클라이언트일 경우 방 제거는 Room 에서 처리 후 넘어옴
 	}
 	s.mutex.Unlock()
@@ -99,27 +154,39 @@ func (s *Server) handleCreateRoom(owner *Client) {
 	// 이미 방에 속해있을 경우
 	// 아마도 타이밍 이슈로 인한 케이스
 	if owner.room != nil {
-		log.Printf("Server: Client %s (Nick: %s) tried to create room but already in room %s.", owner.id, owner.nickname, owner.room.id)
+		owner.logger.Warn("Create room denied: already in a room", "room_id", owner.room.id)
 		errorMsg := Message{Type: MessageTypeError, Payload: ErrorPayload{Message: "이미 다른 방에 참여중입니다."}}
 		payloadBytes, _ := json.Marshal(errorMsg)
 		owner.send <- payloadBytes
 		return
 	}
 
-	roomID := GenerateRandomRoomID()
+	if s.IsShuttingDown() {
+		s.sendError(owner, "서버가 곧 종료되어 방을 만들 수 없습니다.")
+		return
+	}
+	if s.IsMaintenance() {
+		s.sendError(owner, "서버 점검 중에는 방을 만들 수 없습니다.")
+		return
+	}
+
+	roomID := s.newRoomID()
 
 	s.mutex.Lock()
 
-	for _, exists := s.rooms[roomID]; exists; _, exists = s.rooms[roomID] {
-		roomID = GenerateRandomRoomID()
+	if maxRooms := s.config.Limits.MaxRooms; maxRooms > 0 && len(s.rooms) >= maxRooms {
+		s.mutex.Unlock()
+		owner.logger.Warn("Create room denied: room limit reached", "max_rooms", maxRooms)
+		s.sendError(owner, "방이 너무 많아 새 방을 만들 수 없습니다. 잠시 후 다시 시도해주세요.")
+		return
 	}
 
-	room := NewRoom(roomID, owner, s, defaultMaxPlayers)
+	room := NewRoom(roomID, owner, s, s.config.Gameplay.MaxPlayers)
 	s.rooms[roomID] = room
 
 	s.mutex.Unlock()
 
-	log.Printf("Server: Room %s created by %s (Nick: %s). Total rooms: %d", room.id, owner.id, owner.nickname, len(s.rooms))
+	room.logger.Info("Room created", "client_id", owner.id, "nickname", owner.nickname, "total_rooms", len(s.rooms))
 
 	// 방 생성자에게 방 정보 전송
 	createdMsgPayload := RoomInfo{
@@ -129,6 +196,7 @@ func (s *Server) handleCreateRoom(owner *Client) {
 		MaxPlayers:     room.maxPlayers,
 		State:          room.state,
 		CurrentPlayers: 1,
+		Settings:       room.settings,
 	}
 	createdMsg := Message{Type: MessageTypeRoomCreated, Payload: createdMsgPayload}
 	payloadBytes, _ := json.Marshal(createdMsg)
@@ -138,13 +206,29 @@ func (s *Server) handleCreateRoom(owner *Client) {
 	s.broadcastRoomUpdateToAll()
 }
 
+// 사용 가능한 방 ID 생성
+// 로컬 방, 다른 노드의 방 ID와 겹치지 않도록 확인
+// 방은 run 고루틴에서만 추가되므로 확인 후 생성 전까지 겹칠 일 없음
+func (s *Server) newRoomID() string {
+	for {
+		roomID := GenerateRandomRoomID()
+		s.mutex.RLock()
+		_, exists := s.rooms[roomID]
+		s.mutex.RUnlock()
+		// 다른 노드의 방은 디렉터리 고루틴이 갱신한 캐시로 확인
+		if !exists && !s.isRoomIDTakenRemotely(roomID) {
+			return roomID
+		}
+	}
+}
+
 func (s *Server) handleJoinRoom(msg *Message) {
 	client := msg.Sender
 
 	var joinPayload JoinRoomPayload
 	payloadBytes, _ := json.Marshal(msg.Payload)
 	if err := json.Unmarshal(payloadBytes, &joinPayload); err != nil {
-		log.Printf("Server: Failed to parse join room payload from %s: %v", client.id, err)
+		client.logger.Warn("Invalid join room payload", "error", err)
 		errorMsg := Message{Type: MessageTypeError, Payload: ErrorPayload{Message: "잘못된 방 참가 요청입니다."}}
 		responseBytes, _ := json.Marshal(errorMsg)
 		client.send <- responseBytes
@@ -152,12 +236,21 @@ func (s *Server) handleJoinRoom(msg *Message) {
 	}
 	roomID := joinPayload.RoomID
 
+	if s.IsShuttingDown() {
+		s.sendError(client, "서버가 곧 종료되어 방에 참가할 수 없습니다.")
+		return
+	}
+
 	s.mutex.RLock()
 	room, ok := s.rooms[roomID]
 	s.mutex.RUnlock()
 
 	if !ok {
-		log.Printf("Server: Client %s (Nick: %s) tried to join non-existent room %s.", client.id, client.nickname, roomID)
+		// 다른 노드에 있는 방이면 리다이렉트
+		if client.room == nil && s.redirectToRemoteRoom(client, roomID) {
+			return
+		}
+		client.logger.Info("Join denied: room does not exist", "room_id", roomID)
 		errorMsg := Message{Type: MessageTypeError, Payload: ErrorPayload{Message: "존재하지 않는 방입니다."}}
 		payloadBytes, _ := json.Marshal(errorMsg)
 		client.send <- payloadBytes
@@ -166,7 +259,7 @@ func (s *Server) handleJoinRoom(msg *Message) {
 
 	// 이미 방에 속해있을 경우
 	if client.room != nil && client.room.id != roomID {
-		log.Printf("Server: Client %s (Nick: %s) tried to join room %s but already in room %s.", client.id, client.nickname, roomID, client.room.id)
+		client.logger.Warn("Join denied: already in another room", "room_id", roomID, "current_room_id", client.room.id)
 		errorMsg := Message{Type: MessageTypeError, Payload: ErrorPayload{Message: "이미 다른 방에 참여중입니다. 먼저 해당 방에서 나가주세요."}}
 		payloadBytes, _ := json.Marshal(errorMsg)
 		client.send <- payloadBytes
@@ -175,24 +268,36 @@ func (s *Server) handleJoinRoom(msg *Message) {
 
 	// 이미 해당 방에 들어가 있을 경우
 	if client.room != nil && client.room.id == roomID {
-		log.Printf("Server: Client %s (Nick: %s) tried to join room %s but is already in it.", client.id, client.nickname, roomID)
+		client.logger.Debug("Join ignored: already in room", "room_id", roomID)
 		room.sendRoomInfoToClient(client)
 		return
 	}
 
+	// 점검 중에는 방 생성과 마찬가지로 새로 방에 들어갈 수 없음
+	if s.IsMaintenance() {
+		client.logger.Info("Join denied: maintenance mode", "room_id", roomID)
+		s.sendError(client, "서버 점검 중에는 방에 참가할 수 없습니다.")
+		return
+	}
+
 	// Room의 register 채널로 클라이언트 전달하여 방 참여 처리
-	room.register <- client
+	if !sendToRoom(room, room.register, client) {
+		s.sendError(client, "존재하지 않는 방입니다.")
+	}
 }
 
 func (s *Server) handleListRooms(client *Client) {
 	s.sendRoomListToClient(client)
 }
 
-func (s *Server) sendRoomListToClient(client *Client) {
-	s.mutex.RLock()
-
-	roomListItems := make([]RoomListItem, 0, len(s.rooms))
-	for _, room := range s.rooms {
+// 방 목록 생성
+// 로컬 방 + 방 디렉터리에 등록된 다른 노드의 방
+// s.mutex를 잡지 않은 상태에서 호출
+func (s *Server) buildRoomList() []RoomListItem {
+	_, nodeID, _ := s.directoryInfo()
+	rooms := s.roomSnapshot()
+	roomListItems := make([]RoomListItem, 0, len(rooms))
+	for _, room := range rooms {
 		room.mutex.RLock()
 		currentPlayers := len(room.clients)
 		roomState := room.state
@@ -203,62 +308,63 @@ func (s *Server) sendRoomListToClient(client *Client) {
 			CurrentPlayers: currentPlayers,
 			MaxPlayers:     room.maxPlayers,
 			State:          roomState,
+			NodeID:         nodeID,
 		})
 	}
+	return append(roomListItems, s.cachedRemoteRooms()...)
+}
 
-	s.mutex.RUnlock()
+func (s *Server) sendRoomListToClient(client *Client) {
+	roomListItems := s.buildRoomList()
 
 	payload := RoomListPayload{Rooms: roomListItems}
 	msg := Message{Type: MessageTypeRoomListUpdated, Payload: payload}
 
 	responseBytes, err := json.Marshal(msg)
 	if err != nil {
-		log.Printf("Server: Error marshalling room list for client %s: %v", client.id, err)
+		metrics.marshalErrors.Add(1)
+		client.logger.Error("Failed to marshal room list", "error", err)
 		return
 	}
 	client.send <- responseBytes
-	log.Printf("Server: Sent room list to client %s (Nick: %s). %d rooms.", client.id, client.nickname, len(roomListItems))
+	client.logger.Debug("Sent room list", "rooms", len(roomListItems))
 }
 
 // 방 전체 목록 모든 유저들에게 Broadcast
 func (s *Server) broadcastRoomUpdateToAll() {
-	s.mutex.RLock()
+	// 다른 노드에서도 바로 보이도록 디렉터리 고루틴에 방 정보 등록 요청
+	s.requestDirectorySync()
+	s.broadcastRoomListToLobby()
+}
 
-	roomListItems := make([]RoomListItem, 0, len(s.rooms))
-	for _, room := range s.rooms {
-		room.mutex.RLock()
-		currentPlayers := len(room.clients)
-		roomState := room.state
-		room.mutex.RUnlock()
-		roomListItems = append(roomListItems, RoomListItem{
-			ID:             room.id,
-			CurrentPlayers: currentPlayers,
-			MaxPlayers:     room.maxPlayers,
-			State:          roomState,
-		})
-	}
+// 방 목록 로비에 Broadcast (디렉터리 등록 없이)
+func (s *Server) broadcastRoomListToLobby() {
+	roomListItems := s.buildRoomList()
 	payload := RoomListPayload{Rooms: roomListItems}
 	msg := Message{Type: MessageTypeRoomListUpdated, Payload: payload}
 
 	responseBytes, err := json.Marshal(msg)
 	if err != nil {
-		log.Printf("Server: Error marshalling room list for broadcast: %v", err)
+		metrics.marshalErrors.Add(1)
+		s.logger.Error("Failed to marshal room list broadcast", "error", err)
 		return
 	}
 
+	s.mutex.RLock()
 	for _, client := range s.clients {
 		if client.room == nil {
 			select {
 			case client.send <- responseBytes:
 			default:
-				log.Printf("Server: Client %s send channel full during room list broadcast. Message not sent.", client.id)
+				metrics.droppedSends.Inc("server")
+				client.logger.Warn("Send channel full, room list not sent")
 			}
 		}
 	}
 
 	s.mutex.RUnlock()
 
-	log.Printf("Server: Broadcasted room list update to lobby clients. %d rooms.", len(roomListItems))
+	s.logger.Debug("Broadcasted room list to lobby", "rooms", len(roomListItems))
 }
 
 // 방 전체 목록 로비에 있는 유저들에게 Broadcast
@@ -271,8 +377,11 @@ func (s *Server) broadcastRoomUpdate() {
 func (s *Server) handleRemoveRoom(roomID string) {
 	s.mutex.Lock()
 	delete(s.rooms, roomID)
+	if s.directory != nil {
+		s.removedRoomIDs = append(s.removedRoomIDs, roomID)
+	}
 	s.mutex.Unlock()
-	log.Printf("Server: Room %s removed. Total rooms: %d", roomID, len(s.rooms))
+	s.logger.Info("Room removed", "room_id", roomID, "total_rooms", len(s.rooms))
 
 	s.broadcastRoomUpdateToAll()
 }
@@ -298,8 +407,28 @@ func (s *Server) handleRoutedMessage(msg *Message) {
 		s.handleListRooms(client)
 	case MessageTypeSetNicknameColor:
 		s.handleSetNicknameColor(msg)
+	case MessageTypeWatchReplay:
+		s.handleWatchReplay(msg)
+	case MessageTypeReplayControl:
+		s.handleReplayControl(msg)
 	default:
-		log.Printf("Server: Received unhandled routed message type %s from %s (Nick: %s)", msg.Type, client.id, client.nickname)
+		client.logger.Warn("Unhandled routed message", "type", msg.Type)
+	}
+}
+
+// 클라이언트에게 에러 메세지 전송
+func (s *Server) sendError(client *Client, message string) {
+	s.sendErrorPayload(client, ErrorPayload{Message: message})
+}
+
+func (s *Server) sendErrorPayload(client *Client, payload ErrorPayload) {
+	errorMsg := Message{Type: MessageTypeError, Payload: payload}
+	payloadBytes, _ := json.Marshal(errorMsg)
+	select {
+	case client.send <- payloadBytes:
+	default:
+		metrics.droppedSends.Inc("server")
+		client.logger.Warn("Send channel full, error message not sent")
 	}
 }
 
@@ -309,51 +438,132 @@ func (s *Server) handleSetNicknameColor(msg *Message) {
 	var payload SetNicknameColorPayload
 	payloadBytes, _ := json.Marshal(msg.Payload)
 	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
-		log.Printf("Server: Failed to parse set nickname/color payload from %s: %v", client.id, err)
+		client.logger.Warn("Invalid set nickname/color payload", "error", err)
 		return
 	}
 
-	// 클라이언트 정보 업데이트
-	s.mutex.Lock()
-	oldNickname := client.nickname
-	client.nickname = payload.Nickname
-	client.color = payload.Color
-	client.character = payload.Character
-	s.mutex.Unlock()
+	// 프로필 검증
+	nickname, code, reason := validateNickname(payload.Nickname)
+	if code == "" && !isValidColor(payload.Color) {
+		code, reason = ErrorCodeInvalidColor, "색상은 #RRGGBB 형식이어야 합니다."
+	}
+	if _, ok := lookupCharacter(payload.Character); code == "" && !ok {
+		code, reason = ErrorCodeUnknownCharacter, "존재하지 않는 캐릭터입니다."
+	}
+	if code != "" {
+		client.logger.Info("Profile update rejected", "code", code, "nickname", payload.Nickname, "color", payload.Color, "character", payload.Character)
+		s.sendCodedError(client, code, reason)
+		return
+	}
+
+	// 방에 있으면 중복 확인과 변경을 같은 r.mutex Lock 안에서 처리
+	// 따로 잡으면 두 클라이언트가 동시에 같은 닉네임으로 바꿀 수 있음
+	room, oldNickname, ok := s.applyProfile(client, nickname, payload.Color, payload.Character)
+	if !ok {
+		client.logger.Info("Profile update rejected", "code", ErrorCodeNicknameTaken, "nickname", payload.Nickname, "color", payload.Color, "character", payload.Character)
+		s.sendCodedError(client, ErrorCodeNicknameTaken, "방에 같은 닉네임을 쓰는 플레이어가 있습니다.")
+		return
+	}
+
+	client.logger.Info("Profile updated", "old_nickname", oldNickname, "nickname", nickname, "color", payload.Color, "character", payload.Character)
 
-	log.Printf("Server: Client %s updated profile. Nickname: %s -> %s, Color: %s, Character: %s", client.id, oldNickname, client.nickname, client.color, client.character)
+	updatedMsg := Message{Type: MessageTypeProfileUpdated, Payload: ProfileUpdatedPayload{
+		Nickname:  nickname,
+		Color:     payload.Color,
+		Character: payload.Character,
+	}}
+	updatedBytes, _ := json.Marshal(updatedMsg)
+	select {
+	case client.send <- updatedBytes:
+	default:
+		metrics.droppedSends.Inc("server")
+	}
 
 	// 방에 이미 들어가있다면 방의 멤버에게 모두 Broadcast
 	// 현재는 불가능한 케이스이지만 추후 방에서 캐릭터 변경 가능할 시 추가
-	if client.room != nil {
-		client.room.broadcastRoomState()
-		log.Printf("Server: Notified room %s about profile update for %s", client.room.id, client.id)
+	if room != nil {
+		room.broadcastRoomState()
+		room.logger.Debug("Notified room about profile update", "client_id", client.id)
 	}
 }
 
-// Websocket Upgrader
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		origin := r.Header.Get("Origin")
-		// 개발용 localhost이거나 배포용 도메인일 경우 허용
-		return origin == "http://localhost:8080" || origin == "https://cas3205.myeonghoonlee.cloud"
-	},
+// 프로필 변경
+// 방에 있으면 r.mutex Lock 안에서 닉네임 중복 확인 후 변경, 중복이면 ok=false
+// 방 참가와 겹치면 참가 처리 쪽이 profileMutex를 잡고 client.room을 설정하므로 다시 시도
+// s.mutex는 잡지 않음 (방 고루틴이 r.mutex -> s.mutex 순서로 잡지 않도록)
+func (s *Server) applyProfile(client *Client, nickname, color, character string) (room *Room, oldNickname string, ok bool) {
+	for {
+		room = client.lockRoom()
+		client.profileMutex.Lock()
+		if room == nil && client.room != nil {
+			// 그 사이에 방에 들어감
+			client.profileMutex.Unlock()
+			continue
+		}
+
+		if room != nil && room.isNicknameTaken(nickname, client) {
+			client.profileMutex.Unlock()
+			room.mutex.Unlock()
+			return room, client.nickname, false
+		}
+		oldNickname = client.nickname
+		client.nickname = nickname
+		client.color = color
+		client.character = character
+		client.profileMutex.Unlock()
+		if room != nil {
+			room.mutex.Unlock()
+		}
+		return room, oldNickname, true
+	}
+}
+
+// 최대 접속자 수 도달 여부
+func (s *Server) isFull() bool {
+	maxClients := s.config.Limits.MaxClients
+	if maxClients <= 0 {
+		return false
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.clients) >= maxClients
 }
 
 func ServeWs(server *Server, w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Printf("ServeWs: Failed to upgrade connection: %v", err)
+	// 종료 중에는 새 연결 거부
+	if server.IsShuttingDown() {
+		metrics.rejectedConns.Inc("shutting_down")
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	if server.isFull() {
+		metrics.rejectedConns.Inc("max_clients")
+		server.logger.Warn("Connection rejected: client limit reached", "remote_addr", r.RemoteAddr, "max_clients", server.config.Limits.MaxClients)
+		http.Error(w, "server is full", http.StatusServiceUnavailable)
+		return
+	}
+
+	// IP별 연결 수 제한
+	ip := remoteIP(r)
+	if !server.acquireIPSlot(ip) {
+		metrics.rejectedConns.Inc("per_ip")
+		server.logger.Warn("Connection rejected: too many connections from IP", "remote_addr", r.RemoteAddr, "max_per_ip", server.config.Limits.MaxConnectionsPerIP)
+		http.Error(w, "too many connections", http.StatusTooManyRequests)
 		return
 	}
-	log.Printf("ServeWs: Client connected from %s", conn.RemoteAddr().String())
 
+	conn, err := server.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		server.releaseIPSlot(ip)
+		server.logger.Warn("Failed to upgrade connection", "remote_addr", r.RemoteAddr, "error", err)
+		return
+	}
 	// 클라이언트 ID 생성
 	clientID := GenerateUniqueID()
 
 	client := NewClient(server, conn, clientID)
+	client.remoteIP = ip
+	client.logger.Info("Client connected", "remote_addr", conn.RemoteAddr().String())
 	// 서버의 register 채널로 Client 전달
 	server.register <- client
 
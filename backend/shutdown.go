@@ -0,0 +1,134 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	shutdownNoticeInterval = time.Second            // 종료 예고 전송 주기
+	shutdownReason         = "server_shutdown"      // 서버 종료로 게임이 끝날 때 game_ended 사유
+	shutdownCloseReason    = "server shutting down" // close frame 사유
+)
+
+// 서버 종료 중 여부
+func (s *Server) IsShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+// 서버 종료
+// 1. 새 연결, 방 생성/참가, 게임 시작 거부
+// 2. grace 동안 매초 종료 예고 전송, 진행 중인 게임이 모두 끝나면 바로 다음 단계로
+// 3. 남은 게임은 server_shutdown 사유로 종료 (game_ended 전송)
+// 4. 모든 클라이언트에게 close frame 전송 후 연결 해제 대기
+// ctx가 먼저 끝나면 남은 단계를 즉시 진행
+func (s *Server) Shutdown(ctx context.Context, grace time.Duration) {
+	if s.shuttingDown.Swap(true) {
+		return
+	}
+	s.logger.Info("Shutting down, waiting for running games", "grace", grace)
+
+	deadline := time.Now().Add(grace)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	ticker := time.NewTicker(shutdownNoticeInterval)
+	defer ticker.Stop()
+
+drain:
+	for {
+		secondsLeft := int(math.Ceil(time.Until(deadline).Seconds()))
+		if secondsLeft < 0 {
+			secondsLeft = 0
+		}
+		s.broadcastShutdownNotice(secondsLeft)
+
+		if secondsLeft == 0 || len(s.activeGames()) == 0 {
+			break
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			break drain
+		}
+	}
+
+	// 시간 내에 끝나지 않은 게임 종료
+	for _, game := range s.activeGames() {
+		game.StopGame(shutdownReason)
+	}
+
+	// 방 디렉터리에서 이 노드의 방 제거
+	rooms := s.roomSnapshot()
+	roomIDs := make([]string, 0, len(rooms))
+	for _, room := range rooms {
+		roomIDs = append(roomIDs, room.id)
+	}
+	s.removeFromDirectory(roomIDs...)
+
+	s.mutex.RLock()
+	clients := make([]*Client, 0, len(s.clients))
+	for _, client := range s.clients {
+		clients = append(clients, client)
+	}
+	s.mutex.RUnlock()
+
+	// game_ended 등 남은 메세지 전송 후 close frame
+	for _, client := range clients {
+		client.Disconnect(websocket.CloseGoingAway, shutdownCloseReason)
+	}
+
+	// 모든 연결 정리 대기
+	for _, client := range clients {
+		select {
+		case <-client.readDone:
+		case <-ctx.Done():
+			s.logger.Warn("Shutdown timed out while closing connections")
+			return
+		}
+	}
+	s.logger.Info("All connections closed", "clients", len(clients))
+}
+
+// 진행 중(로딩, 카운트다운 포함)인 게임 목록
+func (s *Server) activeGames() []*Game {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	games := make([]*Game, 0)
+	for _, room := range s.rooms {
+		room.mutex.RLock()
+		if room.state == RoomStatePlaying && room.game != nil {
+			games = append(games, room.game)
+		}
+		room.mutex.RUnlock()
+	}
+	return games
+}
+
+// 모든 클라이언트에게 종료 예고 전송
+func (s *Server) broadcastShutdownNotice(secondsLeft int) {
+	msg := Message{Type: MessageTypeServerShuttingDown, Payload: ServerShuttingDownPayload{SecondsLeft: secondsLeft}}
+	payloadBytes, err := json.Marshal(msg)
+	if err != nil {
+		metrics.marshalErrors.Add(1)
+		s.logger.Error("Failed to marshal shutdown notice", "error", err)
+		return
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, client := range s.clients {
+		select {
+		case client.send <- payloadBytes:
+		default:
+			metrics.droppedSends.Inc("server")
+			client.logger.Warn("Send channel full, shutdown notice not sent")
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package backend
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	awardMVP          = "mvp"
+	awardMostAccurate = "most_accurate"
+	awardPunchingBag  = "punching_bag"
+
+	minAccuracySwings = 5 // 명중률 수상에 필요한 최소 휘두른 횟수
+)
+
+// 플레이어 전투 기록
+// 게임 중에 누적하고 game_ended에 포함
+type CombatStats struct {
+	Kills         int     `json:"kills"`
+	Deaths        int     `json:"deaths"`
+	DamageDealt   int     `json:"damage_dealt"`
+	DamageTaken   int     `json:"damage_taken"`
+	Swings        int     `json:"swings"`         // 망치 휘두른 횟수 (충전 공격 포함)
+	Hits          int     `json:"hits"`           // 한 명 이상 맞힌 휘두르기 횟수
+	Accuracy      float64 `json:"accuracy"`       // Hits / Swings (0~1)
+	LongestStreak int     `json:"longest_streak"` // 죽지 않고 연속으로 처치한 최대 수
+	TimeAlive     int64   `json:"time_alive_ms"`  // 살아있던 시간 합계
+	BonusPoints   int     `json:"bonus_points"`   // 점수 규칙으로 얻은 추가 점수
+	PointsLost    int     `json:"points_lost"`    // 죽어서 잃은 점수
+	Bounties      int     `json:"bounties"`       // 1위 처치 현상금 획득 횟수
+	Revenges      int     `json:"revenges"`       // 복수 성공 횟수
+
+	currentStreak int
+	aliveSince    time.Time // 살아있지 않거나 경기 밖이면 zero
+	timeAlive     time.Duration
+}
+
+// 생존 시간 측정 시작
+func (s *CombatStats) markAlive(now time.Time) {
+	if s.aliveSince.IsZero() {
+		s.aliveSince = now
+	}
+}
+
+// 생존 시간 측정 종료 (죽음, 연결 끊김, 게임 종료)
+func (s *CombatStats) markDead(now time.Time) {
+	if s.aliveSince.IsZero() {
+		return
+	}
+	s.timeAlive += now.Sub(s.aliveSince)
+	s.aliveSince = time.Time{}
+}
+
+// 처치 기록
+func (s *CombatStats) addKill() {
+	s.Kills++
+	s.currentStreak++
+	if s.currentStreak > s.LongestStreak {
+		s.LongestStreak = s.currentStreak
+	}
+}
+
+// 죽음 기록
+func (s *CombatStats) addDeath(now time.Time) {
+	s.Deaths++
+	s.currentStreak = 0
+	s.markDead(now)
+}
+
+// 게임 종료 시 계산 값 채움
+func (s *CombatStats) finish(now time.Time) {
+	s.markDead(now)
+	s.TimeAlive = s.timeAlive.Milliseconds()
+	if s.Swings > 0 {
+		s.Accuracy = float64(s.Hits) / float64(s.Swings)
+	}
+}
+
+// 수상
+type Award struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	PlayerID string  `json:"player_id"`
+	Nickname string  `json:"nickname"`
+	Value    float64 `json:"value"` // 수상 기준 값 (점수, 명중률, 받은 피해)
+}
+
+// 게임 종료 수상자 계산
+// 동점이면 플레이어 ID 순으로 앞선 플레이어, 조건을 만족하는 플레이어가 없으면 해당 상 없음
+func computeAwards(scores []PlayerScore) []Award {
+	sorted := make([]PlayerScore, 0, len(scores))
+	for _, score := range scores {
+		if score.Stats != nil {
+			sorted = append(sorted, score)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PlayerID < sorted[j].PlayerID })
+
+	awards := make([]Award, 0, 3)
+	newAward := func(id, title string, score PlayerScore, value float64) Award {
+		return Award{ID: id, Title: title, PlayerID: score.PlayerID, Nickname: score.Nickname, Value: value}
+	}
+
+	// MVP: 점수, 처치, 준 피해 순, 데스가 적을수록 우선
+	var mvp *PlayerScore
+	for i := range sorted {
+		if betterMVP(&sorted[i], mvp) {
+			mvp = &sorted[i]
+		}
+	}
+	if mvp != nil && (mvp.Score > 0 || mvp.Stats.Kills > 0 || mvp.Stats.DamageDealt > 0) {
+		awards = append(awards, newAward(awardMVP, "MVP", *mvp, float64(mvp.Score)))
+	}
+
+	// 명중왕: 최소 횟수 이상 휘두른 플레이어 중 명중률 최고
+	var accurate *PlayerScore
+	for i := range sorted {
+		s := sorted[i].Stats
+		if s.Swings < minAccuracySwings || s.Hits == 0 {
+			continue
+		}
+		if accurate == nil || s.Accuracy > accurate.Stats.Accuracy {
+			accurate = &sorted[i]
+		}
+	}
+	if accurate != nil {
+		awards = append(awards, newAward(awardMostAccurate, "명중왕", *accurate, accurate.Stats.Accuracy))
+	}
+
+	// 동네북: 받은 피해 최다
+	var punchingBag *PlayerScore
+	for i := range sorted {
+		if sorted[i].Stats.DamageTaken == 0 {
+			continue
+		}
+		if punchingBag == nil || sorted[i].Stats.DamageTaken > punchingBag.Stats.DamageTaken {
+			punchingBag = &sorted[i]
+		}
+	}
+	if punchingBag != nil {
+		awards = append(awards, newAward(awardPunchingBag, "동네북", *punchingBag, float64(punchingBag.Stats.DamageTaken)))
+	}
+
+	return awards
+}
+
+// MVP 비교
+func betterMVP(a, b *PlayerScore) bool {
+	if b == nil {
+		return true
+	}
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if a.Stats.Kills != b.Stats.Kills {
+		return a.Stats.Kills > b.Stats.Kills
+	}
+	if a.Stats.DamageDealt != b.Stats.DamageDealt {
+		return a.Stats.DamageDealt > b.Stats.DamageDealt
+	}
+	return a.Stats.Deaths < b.Stats.Deaths
+}
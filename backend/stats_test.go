@@ -0,0 +1,120 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeAwards(t *testing.T) {
+	score := func(id string, points int, stats CombatStats) PlayerScore {
+		stats.finish(time.Time{})
+		return PlayerScore{PlayerID: id, Nickname: id, Score: points, Stats: &stats}
+	}
+
+	tests := []struct {
+		name   string
+		scores []PlayerScore
+		want   map[string]string // 수상 ID -> 플레이어 ID (없으면 수상 없음)
+	}{
+		{
+			name: "clear winners",
+			scores: []PlayerScore{
+				score("a", 5, CombatStats{Kills: 5, Swings: 10, Hits: 6, DamageTaken: 2}),
+				score("b", 2, CombatStats{Kills: 2, Swings: 5, Hits: 4, DamageTaken: 7}),
+			},
+			want: map[string]string{awardMVP: "a", awardMostAccurate: "b", awardPunchingBag: "b"},
+		},
+		{
+			name: "mvp tie broken by kills",
+			scores: []PlayerScore{
+				score("a", 3, CombatStats{Kills: 2}),
+				score("b", 3, CombatStats{Kills: 3}),
+			},
+			want: map[string]string{awardMVP: "b"},
+		},
+		{
+			name: "mvp tie broken by damage dealt",
+			scores: []PlayerScore{
+				score("a", 3, CombatStats{Kills: 3, DamageDealt: 4}),
+				score("b", 3, CombatStats{Kills: 3, DamageDealt: 6}),
+			},
+			want: map[string]string{awardMVP: "b"},
+		},
+		{
+			name: "mvp tie broken by fewer deaths",
+			scores: []PlayerScore{
+				score("a", 3, CombatStats{Kills: 3, DamageDealt: 6, Deaths: 2}),
+				score("b", 3, CombatStats{Kills: 3, DamageDealt: 6, Deaths: 1}),
+			},
+			want: map[string]string{awardMVP: "b"},
+		},
+		{
+			name: "full tie goes to lower player id",
+			scores: []PlayerScore{
+				score("b", 3, CombatStats{Kills: 3, Swings: 6, Hits: 3, DamageTaken: 4}),
+				score("a", 3, CombatStats{Kills: 3, Swings: 6, Hits: 3, DamageTaken: 4}),
+			},
+			want: map[string]string{awardMVP: "a", awardMostAccurate: "a", awardPunchingBag: "a"},
+		},
+		{
+			name: "accuracy needs minimum swings",
+			scores: []PlayerScore{
+				score("a", 1, CombatStats{Kills: 1, Swings: minAccuracySwings - 1, Hits: minAccuracySwings - 1}),
+				score("b", 0, CombatStats{Swings: minAccuracySwings, Hits: 1}),
+			},
+			want: map[string]string{awardMVP: "a", awardMostAccurate: "b"},
+		},
+		{
+			name: "no awards without any activity",
+			scores: []PlayerScore{
+				score("a", 0, CombatStats{Swings: 10}),
+				score("b", 0, CombatStats{}),
+			},
+			want: map[string]string{},
+		},
+		{
+			name:   "players without stats are ignored",
+			scores: []PlayerScore{{PlayerID: "a", Score: 10}},
+			want:   map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			awards := computeAwards(tt.scores)
+			got := make(map[string]string, len(awards))
+			for _, award := range awards {
+				got[award.ID] = award.PlayerID
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("awards = %v, want %v", got, tt.want)
+			}
+			for id, playerID := range tt.want {
+				if got[id] != playerID {
+					t.Fatalf("award %s = %q, want %q (all: %v)", id, got[id], playerID, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCombatStatsStreakAndTimeAlive(t *testing.T) {
+	start := time.Unix(1000, 0)
+	var stats CombatStats
+
+	stats.markAlive(start)
+	stats.addKill()
+	stats.addKill()
+	stats.addKill()
+	stats.addDeath(start.Add(10 * time.Second))
+	stats.addKill()
+	stats.markAlive(start.Add(15 * time.Second))
+	stats.finish(start.Add(20 * time.Second))
+
+	if stats.Kills != 4 || stats.LongestStreak != 3 || stats.currentStreak != 1 {
+		t.Fatalf("kills=%d longest=%d current=%d, want 4, 3, 1", stats.Kills, stats.LongestStreak, stats.currentStreak)
+	}
+	if stats.TimeAlive != (15 * time.Second).Milliseconds() {
+		t.Fatalf("time alive = %dms, want 15000ms", stats.TimeAlive)
+	}
+}
@@ -0,0 +1,282 @@
+package backend
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+const (
+	TeamRed  = "red"
+	TeamBlue = "blue"
+)
+
+// 팀 모드에서 사용하는 팀 목록
+var teamNames = []string{TeamRed, TeamBlue}
+
+// 방 설정
+// 대기실에서 방장이 변경, 게임 시작 시 Game으로 복사
+type RoomSettings struct {
+	TeamMode       bool         `json:"team_mode"`
+	FriendlyFire   bool         `json:"friendly_fire"`
+	ShrinkingArena bool         `json:"shrinking_arena"` // 경기 중 안전 구역 축소
+	Hazards        bool         `json:"hazards"`         // 낙하물
+	Scoring        ScoringRules `json:"scoring"`
+}
+
+// 유효한 팀 이름인지 확인
+func isValidTeam(team string) bool {
+	for _, name := range teamNames {
+		if name == team {
+			return true
+		}
+	}
+	return false
+}
+
+// 팀별 인원 수
+// r.mutex Lock 상태에서 호출
+func (r *Room) teamCounts() map[string]int {
+	counts := make(map[string]int, len(teamNames))
+	for _, name := range teamNames {
+		counts[name] = 0
+	}
+	for c := range r.clients {
+		if isValidTeam(c.team) {
+			counts[c.team]++
+		}
+	}
+	return counts
+}
+
+// 인원이 가장 적은 팀
+// r.mutex Lock 상태에서 호출
+func (r *Room) smallestTeam() string {
+	counts := r.teamCounts()
+	smallest := teamNames[0]
+	for _, name := range teamNames[1:] {
+		if counts[name] < counts[smallest] {
+			smallest = name
+		}
+	}
+	return smallest
+}
+
+// 팀당 최대 인원
+func (r *Room) maxTeamSize() int {
+	return (r.maxPlayers + len(teamNames) - 1) / len(teamNames)
+}
+
+// 팀 자동 분배
+// 입장 순서와 무관하게 ID 순으로 번갈아 배정
+// r.mutex Lock 상태에서 호출
+func (r *Room) balanceTeams() {
+	clients := make([]*Client, 0, len(r.clients))
+	for c := range r.clients {
+		clients = append(clients, c)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].id < clients[j].id })
+
+	for i, c := range clients {
+		c.team = teamNames[i%len(teamNames)]
+	}
+}
+
+// 팀 없는 플레이어를 인원이 적은 팀에 배정
+// r.mutex Lock 상태에서 호출
+func (r *Room) assignMissingTeams() {
+	for c := range r.clients {
+		if !isValidTeam(c.team) {
+			c.team = r.smallestTeam()
+		}
+	}
+}
+
+// 팀 모드에서 게임 시작 가능 여부
+// r.mutex Lock 상태에서 호출
+func (r *Room) canStartTeamGame() (bool, string) {
+	if !r.settings.TeamMode {
+		return true, ""
+	}
+	if len(r.clients) < len(teamNames) {
+		return false, "팀 모드는 최소 2명의 플레이어가 필요합니다."
+	}
+	for _, count := range r.teamCounts() {
+		if count == 0 {
+			return false, "모든 팀에 최소 한 명의 플레이어가 필요합니다."
+		}
+	}
+	return true, ""
+}
+
+// 방 설정 변경 처리
+func (r *Room) handleUpdateRoomSettings(msg *Message) {
+	client := msg.Sender
+
+	var payload UpdateRoomSettingsPayload
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		r.logger.Warn("Invalid room settings payload", "client_id", client.id, "error", err)
+		r.sendError(client, "잘못된 방 설정 요청입니다.")
+		return
+	}
+
+	if payload.Scoring != nil && !payload.Scoring.isValid() {
+		r.logger.Warn("Invalid scoring rules", "client_id", client.id)
+		r.sendError(client, "점수 규칙 값은 0에서 5 사이여야 합니다.")
+		return
+	}
+
+	r.mutex.Lock()
+	if client != r.owner {
+		r.logger.Warn("Room settings denied: not owner", "client_id", client.id)
+		r.mutex.Unlock()
+		r.sendError(client, "방장만 방 설정을 변경할 수 있습니다.")
+		return
+	}
+	if r.state != RoomStateWaiting {
+		r.mutex.Unlock()
+		r.sendError(client, "대기 중에만 방 설정을 변경할 수 있습니다.")
+		return
+	}
+
+	if payload.TeamMode != nil && *payload.TeamMode != r.settings.TeamMode {
+		r.settings.TeamMode = *payload.TeamMode
+		if r.settings.TeamMode {
+			// 팀 모드 켜지면 자동 분배
+			r.balanceTeams()
+		} else {
+			for c := range r.clients {
+				c.team = ""
+			}
+		}
+	}
+	if payload.FriendlyFire != nil {
+		r.settings.FriendlyFire = *payload.FriendlyFire
+	}
+	if payload.ShrinkingArena != nil {
+		r.settings.ShrinkingArena = *payload.ShrinkingArena
+	}
+	if payload.Hazards != nil {
+		r.settings.Hazards = *payload.Hazards
+	}
+	if payload.Scoring != nil {
+		r.settings.Scoring.apply(payload.Scoring)
+	}
+
+	r.logger.Info("Room settings updated", "client_id", client.id, "team_mode", r.settings.TeamMode, "friendly_fire", r.settings.FriendlyFire,
+		"shrinking_arena", r.settings.ShrinkingArena, "hazards", r.settings.Hazards, "scoring", r.settings.Scoring)
+	r.mutex.Unlock()
+
+	r.broadcastRoomState()
+}
+
+// 플레이어 팀 선택 처리
+func (r *Room) handleSetTeam(msg *Message) {
+	client := msg.Sender
+
+	var payload SetTeamPayload
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		r.logger.Warn("Invalid set team payload", "client_id", client.id, "error", err)
+		r.sendError(client, "잘못된 팀 선택 요청입니다.")
+		return
+	}
+
+	r.mutex.Lock()
+	if !r.settings.TeamMode {
+		r.mutex.Unlock()
+		r.sendError(client, "팀 모드가 아닙니다.")
+		return
+	}
+	if r.state != RoomStateWaiting {
+		r.mutex.Unlock()
+		r.sendError(client, "대기 중에만 팀을 변경할 수 있습니다.")
+		return
+	}
+	if !isValidTeam(payload.Team) {
+		r.mutex.Unlock()
+		r.sendError(client, "존재하지 않는 팀입니다.")
+		return
+	}
+	if client.team == payload.Team {
+		r.mutex.Unlock()
+		return
+	}
+	if r.teamCounts()[payload.Team] >= r.maxTeamSize() {
+		r.mutex.Unlock()
+		r.sendError(client, "해당 팀의 인원이 가득 찼습니다.")
+		return
+	}
+
+	client.team = payload.Team
+	// 팀 변경 시 준비 해제
+	client.isReady = false
+	r.logger.Info("Client joined team", "client_id", client.id, "team", client.team)
+	r.mutex.Unlock()
+
+	r.broadcastRoomState()
+}
+
+// 방장 팀 자동 분배 요청 처리
+func (r *Room) handleAutoBalanceTeams(client *Client) {
+	r.mutex.Lock()
+	if client != r.owner {
+		r.logger.Warn("Auto balance denied: not owner", "client_id", client.id)
+		r.mutex.Unlock()
+		return
+	}
+	if !r.settings.TeamMode || r.state != RoomStateWaiting {
+		r.mutex.Unlock()
+		r.sendError(client, "대기 중인 팀 모드 방에서만 팀을 분배할 수 있습니다.")
+		return
+	}
+	r.balanceTeams()
+	r.logger.Info("Teams auto balanced", "client_id", client.id)
+	r.mutex.Unlock()
+
+	r.broadcastRoomState()
+}
+
+// 같은 팀 여부
+// 팀 모드가 아니면 항상 false
+func (g *Game) isTeammate(a, b *PlayerState) bool {
+	return g.settings.TeamMode && a.Team != "" && a.Team == b.Team
+}
+
+// 팀별 점수
+// g.mutex RLock 이상 상태에서 호출
+func (g *Game) teamScores() map[string]int {
+	if !g.settings.TeamMode {
+		return nil
+	}
+	scores := make(map[string]int, len(teamNames))
+	for _, name := range teamNames {
+		scores[name] = 0
+	}
+	for _, ps := range g.players {
+		if isValidTeam(ps.Team) {
+			scores[ps.Team] += ps.Score
+		}
+	}
+	return scores
+}
+
+// 승리 팀
+// 동점일 경우 빈 문자열 (무승부)
+func winningTeam(scores map[string]int) string {
+	winner := ""
+	best := -1
+	for _, name := range teamNames {
+		score, ok := scores[name]
+		if !ok {
+			continue
+		}
+		if score > best {
+			winner = name
+			best = score
+		} else if score == best {
+			winner = ""
+		}
+	}
+	return winner
+}
@@ -0,0 +1,166 @@
+package backend
+
+import (
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 인증서 파일 변경 확인 주기 기본값
+const defaultCertReloadInterval = 30 * time.Second
+
+// TLS 인증서 핫 리로드
+// 새 TLS 핸드셰이크부터 바뀐 인증서를 쓰므로 이미 연결된 WebSocket은 끊기지 않음
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	logger   *slog.Logger
+
+	mutex    sync.RWMutex
+	cert     *tls.Certificate
+	certTime time.Time // 마지막으로 불러온 파일 수정 시각
+	keyTime  time.Time
+
+	// 마지막으로 불러오기에 실패한 수정 시각, Watch 고루틴에서만 접근
+	failedCertTime time.Time
+	failedKeyTime  time.Time
+}
+
+// 인증서를 불러와서 리로더 생성
+func NewCertReloader(certFile, keyFile string, logger *slog.Logger) (*CertReloader, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	r := &CertReloader{certFile: certFile, keyFile: keyFile, logger: logger}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// 인증서 다시 불러오기
+// 실패하면 기존 인증서를 계속 사용
+func (r *CertReloader) Reload() error {
+	certTime, keyTime, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	r.cert = &cert
+	r.certTime = certTime
+	r.keyTime = keyTime
+	r.mutex.Unlock()
+
+	r.logger.Info("TLS certificate loaded", "cert_file", r.certFile, "key_file", r.keyFile)
+	return nil
+}
+
+func (r *CertReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+// 파일이 바뀌었을 때만 다시 불러오기
+func (r *CertReloader) reloadIfChanged() {
+	certTime, keyTime, err := r.modTimes()
+	if err != nil {
+		r.logger.Warn("Failed to stat TLS certificate", "error", err)
+		return
+	}
+
+	r.mutex.RLock()
+	changed := !certTime.Equal(r.certTime) || !keyTime.Equal(r.keyTime)
+	r.mutex.RUnlock()
+	if !changed {
+		return
+	}
+
+	if err := r.Reload(); err != nil {
+		// 인증서, 키 중 하나만 교체된 중간 상태일 수 있으므로 다음 주기에 다시 시도
+		// 같은 파일로 계속 실패하면 한 번만 기록
+		if !certTime.Equal(r.failedCertTime) || !keyTime.Equal(r.failedKeyTime) {
+			r.logger.Warn("Failed to reload changed TLS certificate, keeping the current one", "error", err)
+			r.failedCertTime, r.failedKeyTime = certTime, keyTime
+		}
+	}
+}
+
+// 인증서 파일 변경 감시
+// stop이 닫힐 때까지 interval마다 수정 시각 확인
+func (r *CertReloader) Watch(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = defaultCertReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reloadIfChanged()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.cert == nil {
+		return nil, errors.New("no TLS certificate loaded")
+	}
+	return r.cert, nil
+}
+
+// HTTPS 서버용 TLS 설정
+// HTTP/2와 HTTP/1.1 (WebSocket 업그레이드용) 모두 허용
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
+
+// HTTP 요청을 같은 호스트의 HTTPS 주소로 영구 이동
+// httpsAddr는 HTTPS 리스너 주소 (":443"이면 URL에 포트 생략)
+func NewHTTPSRedirectHandler(httpsAddr string) http.Handler {
+	_, httpsPort, _ := net.SplitHostPort(httpsAddr)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		} else {
+			host = strings.Trim(host, "[]") // 포트 없는 IPv6 주소
+		}
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+	})
+}
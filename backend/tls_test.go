@@ -0,0 +1,185 @@
+package backend
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// 자체 서명 인증서와 키를 PEM 파일로 작성
+// modTime으로 수정 시각을 지정해 변경 감지를 확실하게 함
+func writeSelfSignedCert(t *testing.T, certFile, keyFile, commonName string, modTime time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	writePEM(t, certFile, "CERTIFICATE", der, modTime)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER, modTime)
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte, modTime time.Time) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("chtimes %s: %v", path, err)
+	}
+}
+
+// 현재 인증서의 CommonName
+func servedCommonName(t *testing.T, r *CertReloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("GetCertificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func newTestCertReloader(t *testing.T) (*CertReloader, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeSelfSignedCert(t, certFile, keyFile, "first", time.Now().Add(-time.Minute))
+
+	r, err := NewCertReloader(certFile, keyFile, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewCertReloader: %v", err)
+	}
+	return r, certFile, keyFile
+}
+
+func TestCertReloaderReloadsChangedCertificate(t *testing.T) {
+	r, certFile, keyFile := newTestCertReloader(t)
+	if got := servedCommonName(t, r); got != "first" {
+		t.Fatalf("initial certificate = %q, want %q", got, "first")
+	}
+
+	// 파일이 그대로면 다시 불러오지 않음
+	r.reloadIfChanged()
+	if got := servedCommonName(t, r); got != "first" {
+		t.Fatalf("certificate after unchanged reload = %q, want %q", got, "first")
+	}
+
+	writeSelfSignedCert(t, certFile, keyFile, "second", time.Now())
+	r.reloadIfChanged()
+	if got := servedCommonName(t, r); got != "second" {
+		t.Fatalf("certificate after reload = %q, want %q", got, "second")
+	}
+}
+
+func TestCertReloaderWatch(t *testing.T) {
+	r, certFile, keyFile := newTestCertReloader(t)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		r.Watch(10*time.Millisecond, stop)
+		close(done)
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	writeSelfSignedCert(t, certFile, keyFile, "second", time.Now())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for servedCommonName(t, r) != "second" {
+		if time.Now().After(deadline) {
+			t.Fatal("Watch did not pick up the rewritten certificate")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCertReloaderKeepsCertificateOnInvalidFiles(t *testing.T) {
+	r, _, keyFile := newTestCertReloader(t)
+
+	// 인증서와 맞지 않는 키로 교체된 중간 상태
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	now := time.Now()
+	if err := os.Chtimes(keyFile, now, now); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	r.reloadIfChanged()
+	if got := servedCommonName(t, r); got != "first" {
+		t.Fatalf("certificate after failed reload = %q, want %q", got, "first")
+	}
+}
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		httpsAddr string
+		host      string
+		uri       string
+		want      string
+	}{
+		{"default port", ":443", "example.com", "/", "https://example.com/"},
+		{"drops http port", ":443", "example.com:80", "/ws?room=ABC123", "https://example.com/ws?room=ABC123"},
+		{"custom https port", ":8443", "example.com:8080", "/admin", "https://example.com:8443/admin"},
+		{"ipv6 default port", ":443", "[::1]:8080", "/", "https://[::1]/"},
+		{"ipv6 custom port", ":8443", "[::1]:8080", "/metrics", "https://[::1]:8443/metrics"},
+		{"ipv6 without port", ":443", "[::1]", "/", "https://[::1]/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://"+tt.host+tt.uri, nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+
+			NewHTTPSRedirectHandler(tt.httpsAddr).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusMovedPermanently {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusMovedPermanently)
+			}
+			if got := rec.Header().Get("Location"); got != tt.want {
+				t.Fatalf("Location = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"math/big"
+	"regexp"
 	"time"
 )
 
@@ -15,11 +16,14 @@ const (
 	defaultClientID = "ANONYMOUS"
 )
 
+// 파일 이름으로 사용하는 ID 형식 (경로 조작 방지용)
+var safeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 // 랜덤 방 ID 생성
 func GenerateRandomRoomID() string {
 	bytes := make([]byte, roomIDLength)
 	if _, err := io.ReadFull(rand.Reader, bytes); err != nil {
-		log.Printf("Error generating random bytes for Room ID: %v. Falling back to less random method.", err)
+		slog.Warn("Failed to generate random bytes for room ID, falling back to less random method", "error", err)
 		for i := range bytes {
 			num, _ := rand.Int(rand.Reader, big.NewInt(int64(len(roomIDChars))))
 			bytes[i] = roomIDChars[num.Int64()]
@@ -0,0 +1,90 @@
+// loadtest는 로컬 게임 서버에 가상 플레이어를 접속시켜 부하를 측정합니다.
+//
+// 사용 예:
+//
+//	go run ./cmd/loadtest -addr localhost:8080 -players 40 -room-size 4 -duration 60s -pid $(pgrep cas3205)
+//
+// 모든 플레이어가 같은 IP로 접속하므로 서버는 IP별 연결 수 제한을 끄고 실행해야 합니다.
+//
+//	GAME_MAX_CONNECTIONS_PER_IP=0 go run .
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "게임 서버 주소 (host:port)")
+	origin := flag.String("origin", "http://localhost:8080", "WebSocket Origin 헤더")
+	players := flag.Int("players", 8, "가상 플레이어 수")
+	roomSize := flag.Int("room-size", 4, "방 하나에 들어가는 플레이어 수")
+	duration := flag.Duration("duration", 30*time.Second, "게임 시작 후 측정 시간")
+	actionRate := flag.Int("action-rate", 20, "플레이어당 초당 액션 수 (move/look/click)")
+	connectDelay := flag.Duration("connect-delay", 10*time.Millisecond, "플레이어 접속 간격")
+	pid := flag.Int("pid", 0, "CPU/메모리를 측정할 서버 프로세스 PID (0이면 측정 안 함)")
+	flag.Parse()
+
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+
+	if *players <= 0 || *roomSize <= 0 {
+		log.Fatalf("players, room-size는 1 이상이어야 합니다.")
+	}
+
+	cfg := simConfig{
+		url:        fmt.Sprintf("ws://%s/ws", *addr),
+		origin:     *origin,
+		duration:   *duration,
+		actionRate: *actionRate,
+	}
+
+	stats := newStats()
+	var sampler *procSampler
+	if *pid > 0 {
+		sampler = newProcSampler(*pid)
+		go sampler.run(time.Second)
+	}
+
+	// Ctrl+C로 중간 종료 시에도 결과 출력
+	stop := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		log.Println("Interrupted, stopping simulated players...")
+		close(stop)
+	}()
+
+	// 방 단위로 플레이어 묶음 생성
+	var wg sync.WaitGroup
+	started := time.Now()
+	for i := 0; i < *players; i += *roomSize {
+		size := *roomSize
+		if i+size > *players {
+			size = *players - i
+		}
+		group := newRoomGroup(i / *roomSize, size)
+		for j := 0; j < size; j++ {
+			wg.Add(1)
+			p := newSimPlayer(i+j, j == 0, group, cfg, stats, stop)
+			go func() {
+				defer wg.Done()
+				p.run()
+			}()
+			time.Sleep(*connectDelay)
+		}
+	}
+
+	wg.Wait()
+	if sampler != nil {
+		sampler.stop()
+	}
+
+	stats.report(os.Stdout, time.Since(started), sampler)
+}
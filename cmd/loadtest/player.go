@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 시뮬레이션 설정
+type simConfig struct {
+	url        string
+	origin     string
+	duration   time.Duration
+	actionRate int
+}
+
+// 서버 메세지
+type serverMessage struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// 같은 방에 들어가는 플레이어 묶음
+// 방장이 방을 만들면 roomID 채널로 나머지 플레이어에게 전달
+type roomGroup struct {
+	index  int
+	size   int
+	roomID chan string
+}
+
+func newRoomGroup(index, size int) *roomGroup {
+	return &roomGroup{index: index, size: size, roomID: make(chan string, size)}
+}
+
+// 가상 플레이어
+type simPlayer struct {
+	index   int
+	isOwner bool
+	group   *roomGroup
+	cfg     simConfig
+	stats   *stats
+	stop    chan struct{}
+
+	conn      *websocket.Conn
+	writeMu   sync.Mutex
+	id        string
+	readyIDs  map[string]bool
+	lastTick  uint64
+	gameStart chan struct{}
+	gameEnd   chan struct{}
+	closing   chan struct{}
+}
+
+func newSimPlayer(index int, isOwner bool, group *roomGroup, cfg simConfig, st *stats, stop chan struct{}) *simPlayer {
+	return &simPlayer{
+		index:     index,
+		isOwner:   isOwner,
+		group:     group,
+		cfg:       cfg,
+		stats:     st,
+		stop:      stop,
+		readyIDs:  make(map[string]bool),
+		gameStart: make(chan struct{}),
+		gameEnd:   make(chan struct{}),
+		closing:   make(chan struct{}),
+	}
+}
+
+// 플레이어 실행
+// 접속 -> 프로필 설정 -> 방 생성/참가 -> 준비 -> 게임 -> 종료
+func (p *simPlayer) run() {
+	header := http.Header{}
+	header.Set("Origin", p.cfg.origin)
+
+	conn, _, err := websocket.DefaultDialer.Dial(p.cfg.url, header)
+	if err != nil {
+		p.stats.connectFailed()
+		log.Printf("Player %d: dial failed: %v", p.index, err)
+		return
+	}
+	p.conn = conn
+	p.stats.connected()
+	defer func() {
+		// 정상 종료 시 readLoop에서 연결 끊김으로 집계되지 않도록 먼저 표시
+		close(p.closing)
+		conn.Close()
+	}()
+
+	go p.readLoop()
+
+	p.send("set_nickname_color", map[string]string{
+		"nickname":  fmt.Sprintf("load-%d", p.index),
+		"color":     "#888888",
+		"character": "onion",
+	})
+
+	if p.isOwner {
+		p.send("create_room", map[string]string{})
+	} else {
+		select {
+		case roomID := <-p.group.roomID:
+			p.send("join_room", map[string]string{"room_id": roomID})
+		case <-time.After(30 * time.Second):
+			log.Printf("Player %d: timed out waiting for room of group %d", p.index, p.group.index)
+			return
+		case <-p.stop:
+			return
+		}
+	}
+
+	// 게임 시작 대기
+	select {
+	case <-p.gameStart:
+	case <-p.gameEnd:
+		return
+	case <-time.After(60 * time.Second):
+		log.Printf("Player %d: game did not start in time", p.index)
+		return
+	case <-p.stop:
+		return
+	}
+
+	p.actionLoop()
+}
+
+// 게임 중 액션 전송
+func (p *simPlayer) actionLoop() {
+	if p.cfg.actionRate <= 0 {
+		select {
+		case <-time.After(p.cfg.duration):
+		case <-p.gameEnd:
+		case <-p.stop:
+		}
+		return
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(p.cfg.actionRate))
+	defer ticker.Stop()
+	deadline := time.After(p.cfg.duration)
+
+	yaw := rand.Float64() * 2 * math.Pi
+	for {
+		select {
+		case <-ticker.C:
+			p.sendRandomAction(&yaw)
+		case <-deadline:
+			return
+		case <-p.gameEnd:
+			return
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// 실제 플레이어와 비슷한 비율로 move/look/click 전송
+func (p *simPlayer) sendRandomAction(yaw *float64) {
+	r := rand.Float64()
+	switch {
+	case r < 0.5:
+		*yaw += (rand.Float64() - 0.5) * 0.5
+		p.send("player_action", map[string]interface{}{
+			"action_type": "look",
+			"data":        map[string]float64{"yaw": *yaw, "pitch": 0},
+		})
+	case r < 0.9:
+		move := map[string]int{"forward": 0, "backward": 0, "left": 0, "right": 0}
+		keys := []string{"forward", "backward", "left", "right"}
+		move[keys[rand.Intn(len(keys))]] = 1
+		p.send("player_action", map[string]interface{}{
+			"action_type": "move",
+			"data":        move,
+		})
+	default:
+		p.send("player_action", map[string]interface{}{
+			"action_type": "click",
+			"data": map[string]interface{}{
+				"direction": map[string]float64{"x": math.Sin(*yaw), "z": math.Cos(*yaw)},
+			},
+		})
+	}
+}
+
+// 메세지 전송
+func (p *simPlayer) send(msgType string, payload interface{}) {
+	data, err := json.Marshal(map[string]interface{}{"type": msgType, "payload": payload})
+	if err != nil {
+		log.Printf("Player %d: marshal %s failed: %v", p.index, msgType, err)
+		return
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	p.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	if err := p.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		p.stats.writeFailed()
+		return
+	}
+	p.stats.sent(msgType)
+}
+
+// 서버 메세지 수신
+func (p *simPlayer) readLoop() {
+	endOnce := sync.Once{}
+	defer endOnce.Do(func() { close(p.gameEnd) })
+
+	startOnce := sync.Once{}
+	for {
+		_, data, err := p.conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-p.stop:
+			case <-p.closing:
+			default:
+				p.stats.disconnected()
+			}
+			return
+		}
+		receivedAt := time.Now()
+
+		var msg serverMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			p.stats.invalidMessage()
+			continue
+		}
+		p.stats.received(msg.Type, len(data))
+
+		switch msg.Type {
+		case "user_id_assigned":
+			var payload struct {
+				UserID string `json:"user_id"`
+			}
+			json.Unmarshal(msg.Payload, &payload)
+			p.id = payload.UserID
+
+		case "room_created":
+			var payload struct {
+				ID string `json:"id"`
+			}
+			json.Unmarshal(msg.Payload, &payload)
+			for i := 1; i < p.group.size; i++ {
+				p.group.roomID <- payload.ID
+			}
+			p.tryStartGame()
+
+		case "room_joined":
+			p.send("ready_toggle", map[string]string{})
+
+		case "player_ready_changed":
+			var payload struct {
+				PlayerID string `json:"player_id"`
+				IsReady  bool   `json:"is_ready"`
+			}
+			json.Unmarshal(msg.Payload, &payload)
+			p.readyIDs[payload.PlayerID] = payload.IsReady
+			p.tryStartGame()
+
+		case "game_init_data":
+			p.send("game_loading_complete", map[string]string{"player_id": p.id})
+
+		case "game_started":
+			startOnce.Do(func() { close(p.gameStart) })
+
+		case "game_state_update":
+			var payload struct {
+				Tick       uint64 `json:"tick"`
+				ServerTime int64  `json:"server_time"`
+			}
+			json.Unmarshal(msg.Payload, &payload)
+			if payload.ServerTime > 0 {
+				p.stats.stateLatency(receivedAt.Sub(time.UnixMilli(payload.ServerTime)))
+			}
+			if p.lastTick > 0 && payload.Tick > p.lastTick+1 {
+				p.stats.missedTicks(payload.Tick - p.lastTick - 1)
+			}
+			p.lastTick = payload.Tick
+
+		case "game_ended":
+			return
+
+		case "error":
+			p.stats.serverError(string(msg.Payload))
+		}
+	}
+}
+
+// 방장은 모든 플레이어가 준비되면 게임 시작
+func (p *simPlayer) tryStartGame() {
+	if !p.isOwner {
+		return
+	}
+	ready := 0
+	for _, isReady := range p.readyIDs {
+		if isReady {
+			ready++
+		}
+	}
+	if ready >= p.group.size-1 {
+		p.send("start_game", map[string]string{})
+	}
+}
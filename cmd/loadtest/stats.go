@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 측정 결과 집계
+type stats struct {
+	mutex sync.Mutex
+
+	connects       int
+	connectFails   int
+	disconnects    int
+	writeFails     int
+	invalid        int
+	missed         uint64
+	bytesReceived  int64
+	sentByType     map[string]int
+	receivedByType map[string]int
+	serverErrors   map[string]int
+	latencies      []time.Duration
+}
+
+func newStats() *stats {
+	return &stats{
+		sentByType:     make(map[string]int),
+		receivedByType: make(map[string]int),
+		serverErrors:   make(map[string]int),
+	}
+}
+
+func (s *stats) connected() {
+	s.mutex.Lock()
+	s.connects++
+	s.mutex.Unlock()
+}
+
+func (s *stats) connectFailed() {
+	s.mutex.Lock()
+	s.connectFails++
+	s.mutex.Unlock()
+}
+
+func (s *stats) disconnected() {
+	s.mutex.Lock()
+	s.disconnects++
+	s.mutex.Unlock()
+}
+
+func (s *stats) writeFailed() {
+	s.mutex.Lock()
+	s.writeFails++
+	s.mutex.Unlock()
+}
+
+func (s *stats) invalidMessage() {
+	s.mutex.Lock()
+	s.invalid++
+	s.mutex.Unlock()
+}
+
+func (s *stats) sent(msgType string) {
+	s.mutex.Lock()
+	s.sentByType[msgType]++
+	s.mutex.Unlock()
+}
+
+func (s *stats) received(msgType string, size int) {
+	s.mutex.Lock()
+	s.receivedByType[msgType]++
+	s.bytesReceived += int64(size)
+	s.mutex.Unlock()
+}
+
+func (s *stats) serverError(payload string) {
+	s.mutex.Lock()
+	s.serverErrors[payload]++
+	s.mutex.Unlock()
+}
+
+func (s *stats) stateLatency(d time.Duration) {
+	s.mutex.Lock()
+	s.latencies = append(s.latencies, d)
+	s.mutex.Unlock()
+}
+
+// 서버 send 채널이 가득 차서 버려진 상태 업데이트 수
+// 클라이언트는 tick 번호의 빈 구간으로만 알 수 있음
+func (s *stats) missedTicks(n uint64) {
+	s.mutex.Lock()
+	s.missed += n
+	s.mutex.Unlock()
+}
+
+// 결과 출력
+func (s *stats) report(w io.Writer, elapsed time.Duration, sampler *procSampler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	fmt.Fprintf(w, "\n=== Load test result (%s) ===\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Connections: ok=%d failed=%d unexpected_disconnects=%d write_failures=%d invalid_messages=%d\n",
+		s.connects, s.connectFails, s.disconnects, s.writeFails, s.invalid)
+
+	fmt.Fprintln(w, "\nSent messages:")
+	printCounts(w, s.sentByType)
+	fmt.Fprintf(w, "\nReceived messages (%.1f KiB total):\n", float64(s.bytesReceived)/1024)
+	printCounts(w, s.receivedByType)
+
+	states := s.receivedByType["game_state_update"]
+	dropRate := 0.0
+	if total := uint64(states) + s.missed; total > 0 {
+		dropRate = float64(s.missed) / float64(total) * 100
+	}
+	fmt.Fprintf(w, "\ngame_state_update: received=%d missed=%d (%.2f%% dropped by server)\n", states, s.missed, dropRate)
+
+	if len(s.latencies) > 0 {
+		sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+		fmt.Fprintf(w, "game_state_update latency: p50=%s p95=%s p99=%s max=%s\n",
+			percentile(s.latencies, 50), percentile(s.latencies, 95), percentile(s.latencies, 99), s.latencies[len(s.latencies)-1])
+	}
+
+	if len(s.serverErrors) > 0 {
+		fmt.Fprintln(w, "\nServer errors:")
+		printCounts(w, s.serverErrors)
+	}
+
+	if sampler != nil {
+		sampler.report(w)
+	}
+}
+
+func printCounts(w io.Writer, counts map[string]int) {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %-24s %d\n", k, counts[k])
+	}
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	idx := len(sorted) * p / 100
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// 서버 프로세스 CPU/메모리 샘플링
+// /proc 기반이므로 Linux에서만 동작
+type procSampler struct {
+	pid      int
+	done     chan struct{}
+	mutex    sync.Mutex
+	samples  int
+	cpuPeak  float64
+	cpuTotal float64
+	rssPeak  int64
+	err      error
+}
+
+func newProcSampler(pid int) *procSampler {
+	return &procSampler{pid: pid, done: make(chan struct{})}
+}
+
+func (ps *procSampler) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prevCPU, err := ps.cpuTicks()
+	if err != nil {
+		ps.setErr(err)
+		return
+	}
+	prevTime := time.Now()
+
+	for {
+		select {
+		case <-ticker.C:
+			cpu, err := ps.cpuTicks()
+			if err != nil {
+				ps.setErr(err)
+				return
+			}
+			rss, err := ps.rssBytes()
+			if err != nil {
+				ps.setErr(err)
+				return
+			}
+			now := time.Now()
+			// clock tick은 대부분 100Hz
+			usage := float64(cpu-prevCPU) / 100 / now.Sub(prevTime).Seconds() * 100
+			prevCPU, prevTime = cpu, now
+
+			ps.mutex.Lock()
+			ps.samples++
+			ps.cpuTotal += usage
+			if usage > ps.cpuPeak {
+				ps.cpuPeak = usage
+			}
+			if rss > ps.rssPeak {
+				ps.rssPeak = rss
+			}
+			ps.mutex.Unlock()
+		case <-ps.done:
+			return
+		}
+	}
+}
+
+func (ps *procSampler) stop() {
+	close(ps.done)
+}
+
+func (ps *procSampler) setErr(err error) {
+	ps.mutex.Lock()
+	ps.err = err
+	ps.mutex.Unlock()
+}
+
+// utime + stime
+func (ps *procSampler) cpuTicks() (int64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", ps.pid))
+	if err != nil {
+		return 0, err
+	}
+	// 프로세스 이름에 공백이 있을 수 있어 ')' 이후부터 파싱
+	fields := strings.Fields(string(data[strings.LastIndexByte(string(data), ')')+1:]))
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("unexpected /proc/%d/stat format", ps.pid)
+	}
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	return utime + stime, nil
+}
+
+func (ps *procSampler) rssBytes() (int64, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/status", ps.pid))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "VmRSS:") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				kb, _ := strconv.ParseInt(fields[1], 10, 64)
+				return kb * 1024, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("VmRSS not found for pid %d", ps.pid)
+}
+
+func (ps *procSampler) report(w io.Writer) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	fmt.Fprintf(w, "\nServer process %d:\n", ps.pid)
+	if ps.err != nil {
+		fmt.Fprintf(w, "  sampling error: %v\n", ps.err)
+	}
+	if ps.samples == 0 {
+		fmt.Fprintln(w, "  no samples")
+		return
+	}
+	fmt.Fprintf(w, "  CPU avg=%.1f%% peak=%.1f%%\n", ps.cpuTotal/float64(ps.samples), ps.cpuPeak)
+	fmt.Fprintf(w, "  RSS peak=%.1f MiB\n", float64(ps.rssPeak)/1024/1024)
+}
@@ -2,7 +2,6 @@ module cas3205
 
 go 1.24.2
 
-require (
-	github.com/google/uuid v1.6.0 // indirect
-	github.com/gorilla/websocket v1.5.3 // indirect
-)
+require github.com/gorilla/websocket v1.5.3
+
+require github.com/google/uuid v1.6.0 // indirect
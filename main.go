@@ -2,46 +2,213 @@ package main
 
 import (
 	"cas3205/backend"
+	"context"
+	"errors"
 	"flag"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 )
 
 func main() {
-	port := flag.String("port", "8080", "Port to listen on")
+	configPath := flag.String("config", os.Getenv("GAME_CONFIG"), "Path to a JSON config file (default: $GAME_CONFIG, empty uses built-in defaults)")
+	port := flag.String("port", "", "Port to listen on (overrides listen_addr from the config)")
+	replayDir := flag.String("replay-dir", "replays", "Directory to store match replays (empty to disable)")
+	roomDirectory := flag.String("room-directory", "", "Shared directory for the multi-node room directory (empty for single node)")
+	nodeID := flag.String("node-id", "", "Unique node name in the room directory (default: hostname:port)")
+	publicURL := flag.String("public-url", "", "Public WebSocket URL of this node used for room redirects (default: ws://localhost:port/ws)")
+	shutdownGrace := flag.Duration("shutdown-grace", 30*time.Second, "How long running games may continue after a shutdown signal")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 45*time.Second, "Hard limit for the whole graceful shutdown")
+	logLevel := flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+	logJSON := flag.Bool("log-json", false, "Write logs as JSON lines")
+	adminToken := flag.String("admin-token", os.Getenv("ADMIN_TOKEN"), "Bearer token for the /admin API (default: $ADMIN_TOKEN, empty disables it)")
 	flag.Parse()
 
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	// 로거 설정
+	// 기본 로거로도 등록해서 log 패키지 출력도 같은 형식으로 기록
+	level, err := backend.ParseLogLevel(*logLevel)
+	if err != nil {
+		log.Fatalf("Log level: %v", err)
+	}
+	logger := backend.NewLogger(os.Stderr, level, *logJSON)
+	slog.SetDefault(logger)
+
+	// 설정 불러오기 (기본값 < 설정 파일 < 환경 변수 < -port)
+	cfg, err := backend.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Config: %v", err)
+	}
+	if *port != "" {
+		cfg.ListenAddr = ":" + *port
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid config:\n%v", err)
+	}
+	_, listenPort, _ := net.SplitHostPort(cfg.ListenAddr)
+	wsScheme := "ws"
+	if cfg.TLS.Enabled() {
+		wsScheme = "wss"
+	}
 
 	// 서버 인스턴스 생성
-	server := backend.NewServer()
+	server := backend.NewServer(cfg, logger)
+
+	// 게임 밸런스 설정
+	balanceStore, err := backend.NewBalanceStore(cfg.Balance.File, cfg.Balance.ApplyToRunningGames, logger)
+	if err != nil {
+		log.Fatalf("Balance: %v", err)
+	}
+	server.SetBalanceStore(balanceStore)
+	logger.Info("Game balance loaded", "version", balanceStore.Current().Version, "file", cfg.Balance.File)
+
+	// 리플레이 저장소 설정
+	if *replayDir != "" {
+		replayStore, err := backend.NewReplayStore(*replayDir, logger)
+		if err != nil {
+			log.Fatalf("Replay store: %v", err)
+		}
+		server.SetReplayStore(replayStore)
+	}
+
+	// 멀티 노드 방 디렉터리 설정
+	if *roomDirectory != "" {
+		directory, err := backend.NewFileRoomDirectory(*roomDirectory)
+		if err != nil {
+			log.Fatalf("Room directory: %v", err)
+		}
+		if *nodeID == "" {
+			hostname, _ := os.Hostname()
+			*nodeID = hostname + ":" + listenPort
+		}
+		if *publicURL == "" {
+			*publicURL = wsScheme + "://localhost:" + listenPort + "/ws"
+		}
+		server.SetRoomDirectory(directory, *nodeID, *publicURL)
+	}
 
 	// 웹소켓 핸들러 등록
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
 		backend.ServeWs(server, w, r)
 	})
 
+	// 리플레이 목록, 다운로드
+	http.HandleFunc("GET /replays", func(w http.ResponseWriter, r *http.Request) {
+		backend.ServeReplayList(server, w, r)
+	})
+	http.HandleFunc("GET /replays/{id}", func(w http.ResponseWriter, r *http.Request) {
+		backend.ServeReplayDownload(server, w, r)
+	})
+
+	// 서버 지표 (Prometheus text format)
+	http.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		backend.ServeMetrics(server, w, r)
+	})
+
+	// 관리자 API, 대시보드
+	if *adminToken != "" {
+		http.Handle("/admin/api/", backend.NewAdminHandler(server, *adminToken))
+		http.HandleFunc("GET /admin", func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, filepath.Join(cfg.StaticDir, "admin.html"))
+		})
+		logger.Info("Admin API enabled", "path", "/admin")
+	}
+
 	// 정적 파일 서빙
-	fs := http.FileServer(http.Dir("./static"))
+	fs := http.FileServer(http.Dir(cfg.StaticDir))
 	http.Handle("/", fs)
 
-	addr := ":" + *port
-	log.Printf("Game server starting on %s", addr)
+	logger.Info("Game server starting", "addr", cfg.ListenAddr, "static_dir", cfg.StaticDir, "allowed_origins", cfg.AllowedOrigins, "tls", cfg.TLS.Enabled())
+
+	httpServer := &http.Server{Addr: cfg.ListenAddr}
+	var redirectServer *http.Server
+	stopWatchers := make(chan struct{})
+	defer close(stopWatchers)
+
+	// 밸런스 파일 변경 감시
+	go server.WatchBalance(time.Duration(cfg.Balance.ReloadInterval), stopWatchers)
+
+	// TLS 설정 (HTTP/2 포함)
+	// 인증서는 파일이 바뀌거나 SIGHUP을 받으면 다시 불러옴
+	if cfg.TLS.Enabled() {
+		certs, err := backend.NewCertReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, logger)
+		if err != nil {
+			log.Fatalf("TLS certificate: %v", err)
+		}
+		httpServer.TLSConfig = certs.TLSConfig()
+		go certs.Watch(time.Duration(cfg.TLS.ReloadInterval), stopWatchers)
+
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				if err := certs.Reload(); err != nil {
+					logger.Error("TLS certificate reload failed, keeping the current one", "error", err)
+				}
+			}
+		}()
+
+		// HTTP → HTTPS 리다이렉트
+		if cfg.TLS.RedirectAddr != "" {
+			redirectServer = &http.Server{Addr: cfg.TLS.RedirectAddr, Handler: backend.NewHTTPSRedirectHandler(cfg.ListenAddr)}
+			go func() {
+				if err := redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					log.Fatalf("Redirect listener: %v", err)
+				}
+			}()
+			logger.Info("HTTP to HTTPS redirect enabled", "addr", cfg.TLS.RedirectAddr)
+		}
+	}
 
 	go func() {
-		if err := http.ListenAndServe(addr, nil); err != nil {
+		var err error
+		if cfg.TLS.Enabled() {
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatalf("ListenAndServe: %v", err)
 		}
 	}()
 
-	log.Printf("WebSocket endpoint available at ws://localhost%s/ws", addr)
+	logger.Info("WebSocket endpoint available", "url", wsScheme+"://localhost:"+listenPort+"/ws")
+
+	// SIGUSR1로 점검 모드 전환
+	maintenance := make(chan os.Signal, 1)
+	signal.Notify(maintenance, syscall.SIGUSR1)
+	go func() {
+		for range maintenance {
+			server.ToggleMaintenance()
+		}
+	}()
 
 	// Graceful Shutdown
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 	<-shutdown
-	log.Println("Server gracefully stopped.")
+	logger.Info("Shutdown signal received, draining rooms")
+
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+
+	// 리스너를 먼저 닫아 새 연결을 받지 않고, WebSocket은 게임 종료 후 정리
+	httpDone := make(chan error, 1)
+	go func() {
+		httpDone <- httpServer.Shutdown(ctx)
+	}()
+	if redirectServer != nil {
+		redirectServer.Shutdown(ctx)
+	}
+	server.Shutdown(ctx, *shutdownGrace)
+
+	if err := <-httpDone; err != nil {
+		logger.Error("HTTP server shutdown failed", "error", err)
+	}
+	logger.Info("Server gracefully stopped")
 }
@@ -18,6 +18,14 @@ class GameRenderer {
     this.playerOverlays = new Map();
     // 플레이어 Halo 관리용
     this.playerHalos = new Map();
+    // 투사체, 범위 효과 메시 관리용
+    this.attackEntityMeshes = new Map();
+    // 낙하물 표시 메시 관리용
+    this.hazardMeshes = new Map();
+    // 핑 마커 메시 관리용
+    this.pingMeshes = new Map();
+    // 안전 구역 경계선
+    this.arenaBoundaryMesh = null;
     this.animationFrameId = null;
     this.mouse = new THREE.Vector2();
     
@@ -143,7 +151,8 @@ class GameRenderer {
 
   handleCanvasClick(event) {
     if (uiManager.mainUiContainer.classList.contains("hidden") &&
-        this.scene && this.camera && this.gameFloor && stateManager.getClientId()) {
+        this.scene && this.camera && this.gameFloor && stateManager.getClientId() &&
+        !stateManager.isWatchingReplay()) {
       
       const canvasBounds = this.renderer.domElement.getBoundingClientRect();
       const mouseX = ((event.clientX - canvasBounds.left) / canvasBounds.width) * 2 - 1;
@@ -325,7 +334,7 @@ class GameRenderer {
       playerMesh.currentAnimationName = animationName;
       
       // 일회성 애니메이션은 한 번만 재생
-      if (['hammer_attack', 'death', 'respawn', 'hit'].includes(animationName)) {
+      if (['hammer_attack', 'heavy_attack', 'death', 'respawn', 'hit', 'dash'].includes(animationName)) {
         playerMesh.currentAction.setLoop(THREE.LoopOnce);
         playerMesh.currentAction.clampWhenFinished = true;
       } else {
@@ -395,6 +404,208 @@ class GameRenderer {
     });
   }
 
+  // 투사체, 범위 효과 표시
+  // 서버 목록에 없는 개체는 제거
+  updateAttackEntities(entities) {
+    if (!this.scene) return;
+
+    const activeIds = new Set();
+    entities.forEach((entity) => {
+      activeIds.add(entity.id);
+      let mesh = this.attackEntityMeshes.get(entity.id);
+      if (!mesh) {
+        mesh = this.createAttackEntityMesh(entity);
+        this.attackEntityMeshes.set(entity.id, mesh);
+        this.scene.add(mesh);
+      }
+      mesh.position.x = entity.x;
+      mesh.position.z = entity.z;
+    });
+
+    this.attackEntityMeshes.forEach((mesh, id) => {
+      if (!activeIds.has(id)) {
+        this.scene.remove(mesh);
+        mesh.geometry.dispose();
+        mesh.material.dispose();
+        this.attackEntityMeshes.delete(id);
+      }
+    });
+  }
+
+  createAttackEntityMesh(entity) {
+    const color = new THREE.Color(entity.color || '#ffffff');
+    if (entity.kind === 'projectile') {
+      const geometry = new THREE.SphereGeometry(entity.radius * 0.6, 16, 16);
+      const material = new THREE.MeshStandardMaterial({ color: color });
+      const mesh = new THREE.Mesh(geometry, material);
+      mesh.position.y = 1;
+      mesh.castShadow = true;
+      return mesh;
+    }
+
+    // 범위 효과는 바닥에 반투명 원
+    const geometry = new THREE.CircleGeometry(entity.radius, 48);
+    const material = new THREE.MeshBasicMaterial({ color: color, transparent: true, opacity: 0.3, depthWrite: false });
+    const mesh = new THREE.Mesh(geometry, material);
+    mesh.rotation.x = -Math.PI / 2;
+    mesh.position.y = 0.05;
+    return mesh;
+  }
+
+  // 안전 구역 경계 표시 (원점 기준 정사각형)
+  updateArena(arena) {
+    if (!this.scene) return;
+
+    if (!arena) {
+      if (this.arenaBoundaryMesh) {
+        this.scene.remove(this.arenaBoundaryMesh);
+        this.arenaBoundaryMesh.geometry.dispose();
+        this.arenaBoundaryMesh.material.dispose();
+        this.arenaBoundaryMesh = null;
+      }
+      return;
+    }
+
+    if (!this.arenaBoundaryMesh) {
+      // 한 변이 2인 정사각형을 경계 크기만큼 scale
+      const points = [
+        new THREE.Vector3(-1, 0, -1),
+        new THREE.Vector3(1, 0, -1),
+        new THREE.Vector3(1, 0, 1),
+        new THREE.Vector3(-1, 0, 1),
+      ];
+      const geometry = new THREE.BufferGeometry().setFromPoints(points);
+      const material = new THREE.LineBasicMaterial({ color: 0xff3333 });
+      this.arenaBoundaryMesh = new THREE.LineLoop(geometry, material);
+      this.arenaBoundaryMesh.position.y = 0.1;
+      this.scene.add(this.arenaBoundaryMesh);
+    }
+    this.arenaBoundaryMesh.scale.set(arena.boundary, 1, arena.boundary);
+  }
+
+  // 낙하물 표시
+  // 낙하 전에는 점점 진해지는 경고 원, 충돌 후에는 잠깐 밝게 표시
+  updateHazards(hazards) {
+    if (!this.scene) return;
+
+    const now = Date.now();
+    const activeIds = new Set();
+    hazards.forEach((hazard) => {
+      activeIds.add(hazard.id);
+      let mesh = this.hazardMeshes.get(hazard.id);
+      if (!mesh) {
+        const geometry = new THREE.CircleGeometry(hazard.radius, 48);
+        const material = new THREE.MeshBasicMaterial({ color: 0xff2200, transparent: true, opacity: 0.15, depthWrite: false });
+        mesh = new THREE.Mesh(geometry, material);
+        mesh.rotation.x = -Math.PI / 2;
+        mesh.position.set(hazard.x, 0.06, hazard.z);
+        this.hazardMeshes.set(hazard.id, mesh);
+        this.scene.add(mesh);
+      }
+
+      if (hazard.impacted) {
+        mesh.material.color.set(0xffaa00);
+        mesh.material.opacity = 0.7;
+      } else {
+        const created = new Date(hazard.created_at).getTime();
+        const impact = new Date(hazard.impact_at).getTime();
+        const progress = Math.min(1, Math.max(0, (now - created) / (impact - created)));
+        mesh.material.opacity = 0.15 + progress * 0.4;
+      }
+    });
+
+    this.hazardMeshes.forEach((mesh, id) => {
+      if (!activeIds.has(id)) {
+        this.scene.remove(mesh);
+        mesh.geometry.dispose();
+        mesh.material.dispose();
+        this.hazardMeshes.delete(id);
+      }
+    });
+  }
+
+  // 핑 마커 표시
+  // 바닥 링과 기둥, 종류별 색상 (기본은 핑을 찍은 플레이어 색), 만료가 가까울수록 흐려짐
+  updatePings(pings) {
+    if (!this.scene) return;
+
+    const kindColors = {
+      danger: 0xff3333,
+      target: 0xff9900,
+      help: 0x33cc66,
+    };
+
+    const now = Date.now();
+    const activeIds = new Set();
+    pings.forEach((ping) => {
+      activeIds.add(ping.id);
+      let marker = this.pingMeshes.get(ping.id);
+      if (!marker) {
+        const color = new THREE.Color(kindColors[ping.kind] ?? (ping.color || '#ffffff'));
+        marker = new THREE.Group();
+
+        const ring = new THREE.Mesh(
+          new THREE.RingGeometry(0.8, 1.1, 32),
+          new THREE.MeshBasicMaterial({ color: color, transparent: true, opacity: 0.8, side: THREE.DoubleSide, depthWrite: false })
+        );
+        ring.rotation.x = -Math.PI / 2;
+        ring.position.y = 0.08;
+
+        const pillar = new THREE.Mesh(
+          new THREE.ConeGeometry(0.3, 1.2, 12),
+          new THREE.MeshBasicMaterial({ color: color, transparent: true, opacity: 0.8 })
+        );
+        // 아래를 가리키는 표시
+        pillar.rotation.x = Math.PI;
+        pillar.position.y = 2.5;
+
+        marker.add(ring);
+        marker.add(pillar);
+        marker.userData = { ring: ring, pillar: pillar };
+        marker.position.set(ping.x, 0, ping.z);
+        this.pingMeshes.set(ping.id, marker);
+        this.scene.add(marker);
+      }
+
+      const created = new Date(ping.created_at).getTime();
+      const expires = new Date(ping.expires_at).getTime();
+      const remaining = Math.min(1, Math.max(0, (expires - now) / (expires - created)));
+      const pulse = 1 + 0.15 * Math.sin(now / 120);
+      marker.userData.ring.scale.set(pulse, pulse, 1);
+      marker.userData.ring.material.opacity = 0.2 + remaining * 0.6;
+      marker.userData.pillar.material.opacity = 0.2 + remaining * 0.6;
+    });
+
+    this.pingMeshes.forEach((marker, id) => {
+      if (!activeIds.has(id)) {
+        this.scene.remove(marker);
+        marker.children.forEach((child) => {
+          child.geometry.dispose();
+          child.material.dispose();
+        });
+        this.pingMeshes.delete(id);
+      }
+    });
+  }
+
+  // 마우스 위치의 바닥 좌표 (핑 위치용)
+  getMouseFloorPoint() {
+    if (!this.scene || !this.camera || !this.gameFloor) return null;
+
+    this.raycaster.setFromCamera(this.mouse, this.camera);
+    const intersects = this.raycaster.intersectObject(this.gameFloor, true);
+    if (intersects.length > 0) {
+      return intersects[0].point;
+    }
+
+    const planeY = this.gameFloor.position.y;
+    const rayOrigin = this.camera.position;
+    const rayDirection = new THREE.Vector3(this.mouse.x, this.mouse.y, 0.5).unproject(this.camera).sub(rayOrigin).normalize();
+    if (rayDirection.y === 0) return null;
+    const t = (planeY - rayOrigin.y) / rayDirection.y;
+    return rayOrigin.clone().add(rayDirection.multiplyScalar(t));
+  }
+
   animateThreeJS() {
     this.animationFrameId = requestAnimationFrame(this.animateThreeJS.bind(this));
     
@@ -521,7 +732,8 @@ class GameRenderer {
   // remote 플레이어들만 서버 정보로 yaw 업데이트
   handleCanvasMouseMove(event) {
     if (uiManager.mainUiContainer.classList.contains("hidden") &&
-        this.scene && this.camera && this.gameFloor && stateManager.getClientId()) {
+        this.scene && this.camera && this.gameFloor && stateManager.getClientId() &&
+        !stateManager.isWatchingReplay()) {
       
       const canvasBounds = this.renderer.domElement.getBoundingClientRect();
 
@@ -628,6 +840,12 @@ class GameRenderer {
       });
       this.playerHalos.clear();
 
+      // 투사체, 범위 효과, 낙하물, 경계선은 아래에서 씬과 함께 정리
+      this.attackEntityMeshes.clear();
+      this.hazardMeshes.clear();
+      this.pingMeshes.clear();
+      this.arenaBoundaryMesh = null;
+
       // 씬의 모든 오브젝트 제거
       while (this.scene.children.length > 0) {
         const object = this.scene.children[0];
@@ -685,6 +903,11 @@ class GameRenderer {
     overlay.className = "player-overlay";
     overlay.style.display = "block";
 
+    // 이모트 (재생 중일 때만 표시)
+    const emoteDiv = document.createElement("div");
+    emoteDiv.className = "player-emote";
+    emoteDiv.style.display = "none";
+
     // 닉네임
     const nameDiv = document.createElement("div");
     nameDiv.className = "player-name";
@@ -701,6 +924,7 @@ class GameRenderer {
     healthFill.style.width = "100%";
 
     healthBarContainer.appendChild(healthFill);
+    overlay.appendChild(emoteDiv);
     overlay.appendChild(nameDiv);
     overlay.appendChild(healthBarContainer);
     overlayContainer.appendChild(overlay);
@@ -708,6 +932,7 @@ class GameRenderer {
     this.playerOverlays.set(playerId, {
       element: overlay,
       nameDiv: nameDiv,
+      emoteDiv: emoteDiv,
       healthFill: healthFill
     });
 
@@ -737,10 +962,34 @@ class GameRenderer {
       overlayData.healthFill.classList.add("medium");
     }
 
+    // 이모트 애니메이션 중이면 말풍선 표시
+    this.updatePlayerEmote(overlayData, playerData.current_animation);
+
     // 살아있는 플레이어는 오버레이 표시
     overlayData.element.style.display = "block";
   }
 
+  updatePlayerEmote(overlayData, animationName) {
+    const emoteIcons = {
+      emote_wave: '👋',
+      emote_taunt: '😜',
+      emote_cheer: '🎉',
+      emote_laugh: '😂',
+    };
+
+    const icon = emoteIcons[animationName];
+    if (!icon) {
+      overlayData.emoteDiv.style.display = "none";
+      overlayData.emoteDiv.textContent = "";
+      return;
+    }
+    // 같은 이모트가 이어지는 동안은 다시 그리지 않음 (등장 애니메이션 유지)
+    if (overlayData.emoteDiv.textContent !== icon) {
+      overlayData.emoteDiv.textContent = icon;
+    }
+    overlayData.emoteDiv.style.display = "block";
+  }
+
   removePlayerOverlay(playerId) {
     const overlayData = this.playerOverlays.get(playerId);
     if (overlayData) {
@@ -1,6 +1,14 @@
 /**
  * 키보드 및 마우스 입력 처리 모듈
  */
+
+// 숫자 키별 이모트
+const EMOTE_KEYS = {
+  Digit1: "wave",
+  Digit2: "taunt",
+  Digit3: "cheer",
+  Digit4: "laugh",
+};
 class InputHandler {
   constructor() {
     this.initEventListeners();
@@ -10,6 +18,41 @@ class InputHandler {
     // 전역 이벤트 리스너
     document.addEventListener("keydown", this.handleKeyDown.bind(this));
     document.addEventListener("keyup", this.handleKeyUp.bind(this));
+
+    // 막기 (마우스 오른쪽 버튼 누르고 있는 동안)
+    document.addEventListener("mousedown", (event) => {
+      if (event.button === 2 && this.isInGame()) {
+        this.sendBlockInput(true);
+      }
+      // 공격 목표 핑 (마우스 휠 클릭)
+      if (event.button === 1 && this.isInGame()) {
+        this.sendPingInput("target");
+        event.preventDefault();
+      }
+    });
+    document.addEventListener("mouseup", (event) => {
+      if (event.button === 2 && this.isInGame()) {
+        this.sendBlockInput(false);
+      }
+    });
+    document.addEventListener("contextmenu", (event) => {
+      if (this.isInGame()) {
+        event.preventDefault();
+      }
+    });
+    // 창을 벗어나면 뗀 입력을 받지 못하므로 막기 해제
+    window.addEventListener("blur", () => {
+      if (this.isInGame()) {
+        this.sendBlockInput(false);
+      }
+    });
+  }
+
+  // 게임 화면인지
+  // 리플레이 시청 중에는 조작 입력을 보내지 않음
+  isInGame() {
+    return uiManager.mainUiContainer.classList.contains("hidden") && !!window.websocketManager &&
+      !stateManager.isWatchingReplay();
   }
 
   // 키 입력 표준화
@@ -44,10 +87,84 @@ class InputHandler {
 
   handleKeyDown(event) {
     // 게임 중이고, 입력 필드에 포커스가 없을 때만 처리
-    if (uiManager.mainUiContainer.classList.contains("hidden") &&
+    if (this.isInGame() &&
         document.activeElement.tagName !== "INPUT" &&
         document.activeElement.tagName !== "TEXTAREA") {
       
+      // 고유 능력 (E)
+      if (event.code === "KeyE" || event.key === "ㄷ") {
+        if (!event.repeat) {
+          this.sendAbilityInput();
+        }
+        event.preventDefault();
+        return;
+      }
+
+      // 충전 공격 (Q 누르고 있다가 떼면 공격)
+      if (event.code === "KeyQ" || event.key === "ㅂ") {
+        if (!event.repeat) {
+          window.websocketManager.sendMessage("player_action", {
+            action_type: "charge_start",
+            data: {},
+          });
+        }
+        event.preventDefault();
+        return;
+      }
+
+      // 던지기 (F, 바라보는 방향으로)
+      if (event.code === "KeyF" || event.key === "ㄹ") {
+        if (!event.repeat) {
+          const yaw = stateManager.getPlayerYaw();
+          window.websocketManager.sendMessage("player_action", {
+            action_type: "throw",
+            data: {
+              direction: { x: Math.sin(yaw), z: Math.cos(yaw) },
+            },
+          });
+        }
+        event.preventDefault();
+        return;
+      }
+
+      // 이모트 (1~4)
+      const emote = EMOTE_KEYS[event.code];
+      if (emote) {
+        if (!event.repeat) {
+          window.websocketManager.sendMessage("player_action", {
+            action_type: "emote",
+            data: { emote: emote },
+          });
+        }
+        event.preventDefault();
+        return;
+      }
+
+      // 핑 (G, Shift+G 위험, H 도움 요청)
+      if (event.code === "KeyG" || event.key === "ㅎ") {
+        if (!event.repeat) {
+          this.sendPingInput(event.shiftKey ? "danger" : "default");
+        }
+        event.preventDefault();
+        return;
+      }
+      if (event.code === "KeyH" || event.key === "ㅗ") {
+        if (!event.repeat) {
+          this.sendPingInput("help");
+        }
+        event.preventDefault();
+        return;
+      }
+
+      // 대시 (Space)
+      if (event.code === "Space") {
+        if (!event.repeat) {
+          this.sendDashInput();
+        }
+        event.preventDefault();
+        return;
+      }
+
       let stateChanged = false;
       const normalizedKey = this.normalizeKey(event);
       
@@ -84,7 +201,13 @@ class InputHandler {
 
   handleKeyUp(event) {
     // 게임 중일 때만 처리
-    if (uiManager.mainUiContainer.classList.contains("hidden")) {
+    if (this.isInGame()) {
+      if (event.code === "KeyQ" || event.key === "ㅂ") {
+        this.sendChargeRelease();
+        event.preventDefault();
+        return;
+      }
+
       let stateChanged = false;
       const normalizedKey = this.normalizeKey(event);
       
@@ -133,6 +256,50 @@ class InputHandler {
       data: moveData,
     });
   }
+
+  // 능력 방향은 서버가 현재 바라보는 방향으로 결정
+  sendAbilityInput() {
+    window.websocketManager.sendMessage("player_action", {
+      action_type: "ability",
+      data: {},
+    });
+  }
+
+  // 충전 공격은 현재 바라보는 방향으로
+  sendChargeRelease() {
+    const yaw = stateManager.getPlayerYaw();
+    window.websocketManager.sendMessage("player_action", {
+      action_type: "charge_release",
+      data: {
+        direction: { x: Math.sin(yaw), z: Math.cos(yaw) },
+      },
+    });
+  }
+
+  sendBlockInput(active) {
+    window.websocketManager.sendMessage("player_action", {
+      action_type: "block",
+      data: { active: active },
+    });
+  }
+
+  // 마우스가 가리키는 바닥 위치에 핑
+  sendPingInput(kind) {
+    const point = window.gameRenderer.getMouseFloorPoint();
+    if (!point) return;
+    window.websocketManager.sendMessage("player_action", {
+      action_type: "ping",
+      data: { x: point.x, z: point.z, kind: kind },
+    });
+  }
+
+  // 대시 방향은 서버가 이동 입력 (없으면 바라보는 방향)으로 결정
+  sendDashInput() {
+    window.websocketManager.sendMessage("player_action", {
+      action_type: "dash",
+      data: {},
+    });
+  }
 }
 
 // 전역 인스턴스 생성
@@ -0,0 +1,139 @@
+/**
+ * 효과음 모듈
+ * 별도 음원 파일 없이 Web Audio 오실레이터로 짧은 효과음 합성
+ */
+
+// 음소거 설정 저장 키
+const MUTE_STORAGE_KEY = "veggie-battler-muted";
+
+class SoundManager {
+  constructor() {
+    this.context = null;
+    this.muted = localStorage.getItem(MUTE_STORAGE_KEY) === "true";
+    this.initEventListeners();
+  }
+
+  initEventListeners() {
+    // 브라우저 정책상 사용자 입력이 있어야 소리를 낼 수 있으므로 첫 입력에 AudioContext 생성
+    const unlock = () => this.unlock();
+    document.addEventListener("pointerdown", unlock);
+    document.addEventListener("keydown", unlock);
+
+    // 음소거 토글 (M)
+    document.addEventListener("keydown", (event) => {
+      if ((event.code === "KeyM" || event.key === "ㅡ") && !event.repeat &&
+          document.activeElement.tagName !== "INPUT" &&
+          document.activeElement.tagName !== "TEXTAREA") {
+        this.toggleMute();
+      }
+    });
+  }
+
+  unlock() {
+    if (!this.context) {
+      const AudioContextClass = window.AudioContext || window.webkitAudioContext;
+      if (!AudioContextClass) return;
+      this.context = new AudioContextClass();
+    }
+    if (this.context.state === "suspended") {
+      this.context.resume();
+    }
+  }
+
+  toggleMute() {
+    this.muted = !this.muted;
+    localStorage.setItem(MUTE_STORAGE_KEY, String(this.muted));
+    logger.logMessage(this.muted ? "효과음을 껐습니다." : "효과음을 켰습니다.");
+  }
+
+  // 게임 이벤트 효과음
+  // 내가 관련된 피격, 처치는 크게, 다른 플레이어끼리의 처치는 작게, 다른 플레이어끼리의 피격은 재생하지 않음
+  playGameEvents(events) {
+    const myId = stateManager.getClientId();
+
+    events.forEach((event) => {
+      switch (event.type) {
+        case "hit":
+          if (event.attacker_id === myId) {
+            this.playHitConfirm();
+          } else if (event.victim_id === myId) {
+            this.playHurt();
+          }
+          break;
+
+        case "kill":
+          if (event.attacker_id === myId) {
+            this.playKill(1);
+          } else if (event.victim_id === myId) {
+            this.playDeath();
+          } else {
+            this.playKill(0.4);
+          }
+          break;
+
+        case "streak":
+          this.playStreak(event.player_id === myId ? 1 : 0.4);
+          break;
+      }
+    });
+  }
+
+  // 때렸을 때 짧고 높은 소리
+  playHitConfirm() {
+    this.tone(660, 0.08, { type: "square", volume: 0.08, slideTo: 880 });
+  }
+
+  // 맞았을 때 낮게 떨어지는 소리
+  playHurt() {
+    this.tone(220, 0.15, { type: "sawtooth", volume: 0.1, slideTo: 110 });
+  }
+
+  // 처치 시 올라가는 두 음
+  playKill(volumeScale) {
+    this.tone(523, 0.1, { type: "triangle", volume: 0.15 * volumeScale });
+    this.tone(784, 0.18, { type: "triangle", volume: 0.15 * volumeScale, delay: 0.09 });
+  }
+
+  // 죽었을 때 내려가는 소리
+  playDeath() {
+    this.tone(392, 0.4, { type: "sawtooth", volume: 0.12, slideTo: 98 });
+  }
+
+  // 연속 처치 팡파레
+  playStreak(volumeScale) {
+    [523, 659, 784, 1047].forEach((freq, i) => {
+      this.tone(freq, 0.12, { type: "triangle", volume: 0.12 * volumeScale, delay: i * 0.08 });
+    });
+  }
+
+  // 오실레이터 한 음 재생
+  tone(freq, duration, { type = "sine", volume = 0.1, delay = 0, slideTo = null } = {}) {
+    if (this.muted || !this.context || this.context.state !== "running") return;
+
+    const start = this.context.currentTime + delay;
+    const oscillator = this.context.createOscillator();
+    const gain = this.context.createGain();
+
+    oscillator.type = type;
+    oscillator.frequency.setValueAtTime(freq, start);
+    if (slideTo) {
+      oscillator.frequency.exponentialRampToValueAtTime(slideTo, start + duration);
+    }
+
+    // 클릭 잡음 없도록 짧게 올렸다가 서서히 줄임
+    gain.gain.setValueAtTime(0.0001, start);
+    gain.gain.exponentialRampToValueAtTime(volume, start + 0.01);
+    gain.gain.exponentialRampToValueAtTime(0.0001, start + duration);
+
+    oscillator.connect(gain);
+    gain.connect(this.context.destination);
+    oscillator.start(start);
+    oscillator.stop(start + duration + 0.02);
+  }
+}
+
+// 전역 인스턴스 생성
+const soundManager = new SoundManager();
+window.soundManager = soundManager;
+
+export { SoundManager, soundManager };
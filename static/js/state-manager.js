@@ -11,6 +11,8 @@ class StateManager {
     this.currentPlayersMap = new Map();
     // 서버에서 받은 방 정보 저장용
     this.roomInfoFromServer = null;
+    // 시청 중인 리플레이 ID (없으면 null)
+    this.watchingReplayId = null;
     
     // 플레이어 입력 상태
     this.playerYaw = 0;
@@ -110,6 +112,19 @@ class StateManager {
     return this.roomInfoFromServer;
   }
 
+  // Replay 관리
+  setWatchingReplay(replayId) {
+    this.watchingReplayId = replayId;
+  }
+
+  clearWatchingReplay() {
+    this.watchingReplayId = null;
+  }
+
+  isWatchingReplay() {
+    return this.watchingReplayId !== null;
+  }
+
   // Player Input 상태 관리
   setPlayerYaw(yaw) {
     this.playerYaw = yaw;
@@ -2,6 +2,19 @@ import { generateNickname } from './nickname-generator.js';
 /**
  * UI 관리 및 화면 전환 모듈
  */
+
+// 봇 난이도 표시 이름
+const BOT_DIFFICULTY_NAMES = {
+  easy: '쉬움',
+  normal: '보통',
+  hard: '어려움',
+};
+
+// 팀 표시 정보
+const TEAM_INFO = {
+  red: { name: '레드', icon: '🔴', color: '#ef4444' },
+  blue: { name: '블루', icon: '🔵', color: '#3b82f6' },
+};
 class UIManager {
   constructor() {
     this.initUIElements();
@@ -27,7 +40,10 @@ class UIManager {
     this.gameHudTopLeft = document.getElementById("game-hud-top-left");
     this.gameHudTopRight = document.getElementById("game-hud-top-right");
     this.gameTimeLeftEl = document.getElementById("game-time-left");
+    this.killFeedEl = document.getElementById("kill-feed");
     this.gameCountdownOverlay = document.getElementById("game-countdown-overlay");
+    this.announcementBanner = document.getElementById("announcement-banner");
+    this.announcementTimer = null;
     this.customCrosshair = document.getElementById("custom-crosshair");
     this.playerOverlays = document.getElementById("player-overlays");
     
@@ -36,6 +52,18 @@ class UIManager {
     this.healthText = document.getElementById("health-text");
     this.respawnTimer = document.getElementById("respawn-timer");
     this.respawnCountdown = document.getElementById("respawn-countdown");
+
+    // 리플레이 관련 UI 요소들
+    this.replayListEl = document.getElementById("replay-list");
+    this.listReplaysButton = document.getElementById("list-replays-button");
+    this.replayControls = document.getElementById("replay-controls");
+    this.replayPauseButton = document.getElementById("replay-pause-button");
+    this.replaySeek = document.getElementById("replay-seek");
+    this.replayTime = document.getElementById("replay-time");
+    this.replaySpeed = document.getElementById("replay-speed");
+    this.replayStopButton = document.getElementById("replay-stop-button");
+    this.replayPaused = false;
+    this.replaySeeking = false;
     
     // 폼 요소들
     this.nicknameInput = document.getElementById("nickname");
@@ -66,6 +94,21 @@ class UIManager {
     this.currentPlayersEl = document.getElementById("current-players");
     this.maxPlayersEl = document.getElementById("max-players");
     this.gameResultDisplay = document.getElementById("game-result-display");
+
+    // 방 설정 요소들
+    this.roomSettingsSummary = document.getElementById("room-settings-summary");
+    this.roomSettingsControls = document.getElementById("room-settings-controls");
+    this.teamModeCheckbox = document.getElementById("setting-team-mode");
+    this.friendlyFireCheckbox = document.getElementById("setting-friendly-fire");
+    this.shrinkingArenaCheckbox = document.getElementById("setting-shrinking-arena");
+    this.hazardsCheckbox = document.getElementById("setting-hazards");
+    this.scoringInputs = document.querySelectorAll(".scoring-input");
+    this.teamControls = document.getElementById("team-controls");
+    this.joinRedTeamButton = document.getElementById("join-red-team-button");
+    this.joinBlueTeamButton = document.getElementById("join-blue-team-button");
+    this.autoBalanceTeamsButton = document.getElementById("auto-balance-teams-button");
+    this.botDifficultySelect = document.getElementById("bot-difficulty");
+    this.addBotButton = document.getElementById("add-bot-button");
   }
 
   initEventListeners() {
@@ -161,6 +204,33 @@ class UIManager {
       }
     });
 
+    this.listReplaysButton.addEventListener("click", () => {
+      this.loadReplayList();
+    });
+
+    // 리플레이 재생 제어
+    this.replayPauseButton.addEventListener("click", () => {
+      window.websocketManager.sendMessage("replay_control", { action: this.replayPaused ? "resume" : "pause" });
+    });
+
+    // 끄는 동안에는 상태 메세지로 위치를 덮어쓰지 않고, 놓을 때 탐색
+    this.replaySeek.addEventListener("input", () => {
+      this.replaySeeking = true;
+      this.replayTime.textContent = `${this.formatReplayTime(Number(this.replaySeek.value))} / ${this.formatReplayTime(Number(this.replaySeek.max))}`;
+    });
+    this.replaySeek.addEventListener("change", () => {
+      this.replaySeeking = false;
+      window.websocketManager.sendMessage("replay_control", { action: "seek", position_ms: Number(this.replaySeek.value) });
+    });
+
+    this.replaySpeed.addEventListener("change", () => {
+      window.websocketManager.sendMessage("replay_control", { action: "speed", speed: Number(this.replaySpeed.value) });
+    });
+
+    this.replayStopButton.addEventListener("click", () => {
+      this.stopReplay();
+    });
+
     // 대기실 버튼들
     this.readyButton.addEventListener("click", () => {
       const newReadyState = stateManager.toggleReady();
@@ -172,6 +242,49 @@ class UIManager {
       window.websocketManager.sendMessage("start_game", {});
     });
 
+    // 방 설정 (방장), 결과는 room_state_updated로 반영
+    this.teamModeCheckbox.addEventListener("change", () => {
+      window.websocketManager.sendMessage("update_room_settings", { team_mode: this.teamModeCheckbox.checked });
+    });
+    this.friendlyFireCheckbox.addEventListener("change", () => {
+      window.websocketManager.sendMessage("update_room_settings", { friendly_fire: this.friendlyFireCheckbox.checked });
+    });
+    this.shrinkingArenaCheckbox.addEventListener("change", () => {
+      window.websocketManager.sendMessage("update_room_settings", { shrinking_arena: this.shrinkingArenaCheckbox.checked });
+    });
+    this.hazardsCheckbox.addEventListener("change", () => {
+      window.websocketManager.sendMessage("update_room_settings", { hazards: this.hazardsCheckbox.checked });
+    });
+
+    // 점수 규칙 (0~5), 범위를 벗어나면 서버 값으로 되돌림
+    this.scoringInputs.forEach((input) => {
+      input.addEventListener("change", () => {
+        const value = Number(input.value);
+        if (!Number.isInteger(value) || value < 0 || value > 5) {
+          alert("점수 규칙 값은 0에서 5 사이여야 합니다.");
+          this.updateRoomSettingsUI();
+          return;
+        }
+        window.websocketManager.sendMessage("update_room_settings", { scoring: { [input.dataset.scoring]: value } });
+      });
+    });
+
+    // 봇 추가 (방장), 제거는 플레이어 목록의 봇 항목에서
+    this.addBotButton.addEventListener("click", () => {
+      window.websocketManager.sendMessage("add_bot", { difficulty: this.botDifficultySelect.value });
+    });
+
+    // 팀 선택
+    this.joinRedTeamButton.addEventListener("click", () => {
+      window.websocketManager.sendMessage("set_team", { team: "red" });
+    });
+    this.joinBlueTeamButton.addEventListener("click", () => {
+      window.websocketManager.sendMessage("set_team", { team: "blue" });
+    });
+    this.autoBalanceTeamsButton.addEventListener("click", () => {
+      window.websocketManager.sendMessage("auto_balance_teams", {});
+    });
+
     this.leaveRoomButton.addEventListener("click", () => {
       window.websocketManager.sendMessage("leave_room", {});
       window.gameRenderer.exitGameView();
@@ -294,6 +407,7 @@ class UIManager {
     this.gameCountdownOverlay.classList.add("hidden");
     this.customCrosshair.classList.add("hidden");
     this.playerOverlays.classList.add("hidden");
+    this.replayControls.classList.add("hidden");
     
     // UI 섹션으로 돌아갈 때 마우스 커서 다시 보이게 하기
     document.body.style.cursor = "default";
@@ -316,6 +430,13 @@ class UIManager {
     document.getElementById("game-hud-top-right").classList.remove("hidden");
     document.getElementById("player-overlays").classList.remove("hidden");
     
+    // 리플레이는 조작하지 않으므로 커서와 재생 제어 표시
+    if (stateManager.isWatchingReplay()) {
+      document.body.style.cursor = "default";
+      this.replayControls.classList.remove("hidden");
+      return;
+    }
+
     // 마우스 커서 숨기고 포인터 보이기
     document.body.style.cursor = "none";
     document.getElementById("custom-crosshair").classList.remove("hidden");
@@ -327,6 +448,7 @@ class UIManager {
     document.getElementById("game-hud-top-right").classList.add("hidden");
     document.getElementById("game-countdown-overlay").classList.add("hidden");
     document.getElementById("player-overlays").classList.add("hidden");
+    this.replayControls.classList.add("hidden");
     
     // 마우스 커서 다시 보이게 하고 포인터 숨기기
     document.body.style.cursor = "default";
@@ -371,6 +493,94 @@ class UIManager {
     }
   }
 
+  // 리플레이 목록 불러오기
+  // 목록은 HTTP로 받고 재생은 웹소켓으로 요청
+  loadReplayList() {
+    this.replayListEl.innerHTML = '<p class="text-gray-500 font-medium">리플레이 목록을 불러오는 중...</p>';
+    fetch("/replays")
+      .then((res) => {
+        if (!res.ok) throw new Error(res.status === 404 ? "리플레이 기능이 비활성화되어 있습니다." : `HTTP ${res.status}`);
+        return res.json();
+      })
+      .then((replays) => this.updateReplayList(replays))
+      .catch((error) => {
+        this.replayListEl.innerHTML = "";
+        const p = document.createElement("p");
+        p.className = "text-gray-500 text-center py-4";
+        p.textContent = `리플레이 목록을 불러오지 못했습니다. (${error.message})`;
+        this.replayListEl.appendChild(p);
+      });
+  }
+
+  updateReplayList(replays) {
+    this.replayListEl.innerHTML = "";
+
+    if (!replays || replays.length === 0) {
+      this.replayListEl.innerHTML = '<p class="text-slate-400 text-center py-4">저장된 리플레이가 없습니다.</p>';
+      return;
+    }
+
+    replays.forEach((replay) => {
+      const item = document.createElement("div");
+      item.className = "p-3 mb-2 rounded-md hover:bg-slate-300 flex justify-between items-center gap-2";
+
+      const players = (replay.players || []).map((p) => `${p.nickname} ${p.score}점`).join(", ");
+      const info = document.createElement("span");
+      info.className = "text-sm";
+      info.textContent = `${new Date(replay.started_at).toLocaleString()} (${this.formatReplayTime(replay.duration_ms)}) - ${players || "플레이어 없음"}`;
+
+      const watchBtn = document.createElement("button");
+      watchBtn.textContent = "보기";
+      watchBtn.className = "font-semibold py-1 px-3 rounded-md shadow-sm text-sm bg-purple-500 hover:bg-purple-600 text-white focus:ring-purple-400 focus:outline-none focus:ring-2 focus:ring-opacity-75 shrink-0";
+      watchBtn.onclick = () => this.watchReplay(replay.id);
+
+      item.appendChild(info);
+      item.appendChild(watchBtn);
+      this.replayListEl.appendChild(item);
+    });
+  }
+
+  // 리플레이 시청 요청
+  // 첫 프레임(game_init_data)을 받으면 게임 화면으로 전환
+  watchReplay(replayId) {
+    stateManager.setWatchingReplay(replayId);
+    this.replayPaused = false;
+    this.replaySeeking = false;
+    this.replaySpeed.value = "1";
+    window.websocketManager.sendMessage("watch_replay", { replay_id: replayId, speed: 1 });
+    logger.logMessage(`리플레이 ${replayId} 불러오는 중...`);
+  }
+
+  // 리플레이 재생 상태 표시
+  updateReplayStatus(status) {
+    this.replayPaused = status.paused || status.finished;
+    this.replayPauseButton.textContent = this.replayPaused ? "▶" : "⏸";
+    this.replayPauseButton.disabled = !!status.finished;
+    this.replaySeek.max = status.duration_ms;
+    if (!this.replaySeeking) {
+      this.replaySeek.value = status.position_ms;
+      this.replayTime.textContent = `${this.formatReplayTime(status.position_ms)} / ${this.formatReplayTime(status.duration_ms)}`;
+    }
+    this.replaySpeed.value = String(status.speed);
+  }
+
+  // 리플레이 시청 종료 후 로비로
+  stopReplay() {
+    if (!stateManager.isWatchingReplay()) return;
+    window.websocketManager.sendMessage("replay_control", { action: "stop" });
+    stateManager.clearWatchingReplay();
+    window.gameRenderer.exitGameView();
+    this.clearKillFeed();
+    this.showMainUISection(this.lobbySection);
+  }
+
+  formatReplayTime(ms) {
+    const totalSeconds = Math.floor((ms || 0) / 1000);
+    const minutes = Math.floor(totalSeconds / 60);
+    const seconds = totalSeconds % 60;
+    return `${minutes}:${seconds.toString().padStart(2, '0')}`;
+  }
+
   updateWaitingRoomUI() {
     const roomInfo = stateManager.getRoomInfo();
     this.roomIdDisplay.textContent = roomInfo.room_id;
@@ -407,6 +617,72 @@ class UIManager {
       this.readyButton.classList.remove("hidden");
       this.startGameButton.classList.add("hidden");
     }
+    this.updateRoomSettingsUI();
+  }
+
+  // 방 설정 표시
+  // 현재 설정은 모두에게 요약으로, 변경 컨트롤은 방장에게만 표시
+  updateRoomSettingsUI() {
+    const settings = stateManager.getRoomInfo()?.settings || {};
+    const isOwner = stateManager.getIsOwner();
+
+    this.roomSettingsSummary.innerHTML = "";
+    this.formatRoomSettings(settings).forEach((text) => {
+      const badge = document.createElement("span");
+      badge.className = "px-2 py-0.5 rounded-full bg-white border border-gray-300 text-gray-700";
+      badge.textContent = text;
+      this.roomSettingsSummary.appendChild(badge);
+    });
+
+    this.roomSettingsControls.classList.toggle("hidden", !isOwner);
+    this.teamModeCheckbox.checked = !!settings.team_mode;
+    this.friendlyFireCheckbox.checked = !!settings.friendly_fire;
+    this.friendlyFireCheckbox.disabled = !settings.team_mode;
+    this.shrinkingArenaCheckbox.checked = !!settings.shrinking_arena;
+    this.hazardsCheckbox.checked = !!settings.hazards;
+    this.scoringInputs.forEach((input) => {
+      input.value = settings.scoring?.[input.dataset.scoring] ?? 0;
+    });
+
+    this.teamControls.classList.toggle("hidden", !settings.team_mode);
+    this.autoBalanceTeamsButton.classList.toggle("hidden", !isOwner);
+  }
+
+  // 방 설정 요약 문구
+  formatRoomSettings(settings) {
+    const items = [];
+    if (settings.team_mode) {
+      items.push(`👥 팀전${settings.friendly_fire ? " (아군 피해 켜짐)" : ""}`);
+    } else {
+      items.push("👤 개인전");
+    }
+    if (settings.shrinking_arena) {
+      items.push("🟥 경기장 축소");
+    }
+    if (settings.hazards) {
+      items.push("📦 낙하물");
+    }
+    return items.concat(this.formatScoringRules(settings.scoring));
+  }
+
+  // 점수 규칙 문구, 모두 0이면 처치당 1점
+  formatScoringRules(scoring = {}) {
+    const items = [];
+    if (scoring.streak_bonus) items.push(`🔥 연속 처치 +${scoring.streak_bonus}`);
+    if (scoring.leader_bounty) items.push(`💰 1위 현상금 +${scoring.leader_bounty}`);
+    if (scoring.revenge_bonus) items.push(`😤 복수 +${scoring.revenge_bonus}`);
+    if (scoring.death_penalty) items.push(`💀 죽으면 -${scoring.death_penalty}`);
+    if (items.length === 0) {
+      items.push("🎯 처치당 1점");
+    }
+    return items;
+  }
+
+  // 팀 배지 HTML
+  teamBadge(team) {
+    const info = TEAM_INFO[team];
+    if (!info) return '';
+    return `<span class="px-2 py-0.5 text-xs font-semibold rounded-full text-white" style="background-color: ${info.color};">${info.icon} ${info.name}</span>`;
   }
 
   updateReadyButtonState(isReady) {
@@ -447,9 +723,15 @@ class UIManager {
       playerStatus.className = "flex items-center space-x-2";
 
       let statusContent = '';
+      if (stateManager.getRoomInfo()?.settings?.team_mode) {
+        statusContent += this.teamBadge(player.team);
+      }
       if (player.id === stateManager.getClientId()) {
         statusContent += '<span class="px-2 py-0.5 text-xs font-semibold bg-yellow-600 text-yellow-100 rounded-full">나</span>';
       }
+      if (player.is_bot) {
+        statusContent += `<span class="px-2 py-0.5 text-xs font-semibold bg-teal-600 text-teal-100 rounded-full">🤖 ${BOT_DIFFICULTY_NAMES[player.bot_difficulty] || '봇'}</span>`;
+      }
       
       if (player.is_owner) {
         statusContent += '<span class="px-2 py-0.5 text-xs font-semibold bg-purple-600 text-purple-100 rounded-full">방장</span>';
@@ -462,6 +744,18 @@ class UIManager {
       
       playerStatus.innerHTML = statusContent;
 
+      // 방장은 봇 제거 가능
+      if (player.is_bot && stateManager.getIsOwner()) {
+        const removeBotBtn = document.createElement("button");
+        removeBotBtn.textContent = "✕";
+        removeBotBtn.title = "봇 제거";
+        removeBotBtn.className = "px-2 text-sm font-bold text-red-500 hover:text-red-700";
+        removeBotBtn.onclick = () => {
+          window.websocketManager.sendMessage("remove_bot", { bot_id: player.id });
+        };
+        playerStatus.appendChild(removeBotBtn);
+      }
+
       playerItem.appendChild(playerInfo);
       playerItem.appendChild(playerStatus);
 
@@ -471,10 +765,20 @@ class UIManager {
 
   updatePlayerCountInRoom() {
     this.currentPlayersEl.textContent = stateManager.getPlayerCount();
+
+    // 방이 가득 차면 봇 추가 불가
+    const roomFull = stateManager.getPlayerCount() >= Number(this.maxPlayersEl.textContent);
+    this.addBotButton.disabled = roomFull;
+    this.addBotButton.classList.toggle("opacity-50", roomFull);
   }
 
   updateGameResultUI(resultPayload) {
     this.gameResultDisplay.innerHTML = `<h3 class="text-2xl font-bold mb-4 text-center">🏆 게임 결과</h3>`;
+
+    // 팀 모드 결과
+    if (resultPayload.team_scores) {
+      this.gameResultDisplay.appendChild(this.createTeamResult(resultPayload.team_scores, resultPayload.winning_team));
+    }
     
     const scoreList = document.createElement("div");
     scoreList.className = "space-y-2";
@@ -536,6 +840,12 @@ class UIManager {
         nameSpan.style.textShadow = '0 0 3px #000, 0 0 3px #000, 0 0 3px #000, 0 0 3px #000';
         nameSpan.textContent = score.nickname || score.player_id.substring(0, 6);
         playerDiv.appendChild(nameSpan);
+
+        if (TEAM_INFO[score.team]) {
+          const teamSpan = document.createElement("span");
+          teamSpan.innerHTML = this.teamBadge(score.team);
+          playerDiv.appendChild(teamSpan);
+        }
         
         if (rankIcon) {
           const iconSpan = document.createElement("span");
@@ -544,8 +854,19 @@ class UIManager {
           playerDiv.appendChild(iconSpan);
         }
         
+        // 이름 아래 전투 기록
+        const infoDiv = document.createElement("div");
+        infoDiv.className = "flex flex-col";
+        infoDiv.appendChild(playerDiv);
+        if (score.stats) {
+          const statsDiv = document.createElement("div");
+          statsDiv.className = "text-xs text-gray-300";
+          statsDiv.textContent = this.formatCombatStats(score.stats);
+          infoDiv.appendChild(statsDiv);
+        }
+        
         leftSection.appendChild(rankDiv);
-        leftSection.appendChild(playerDiv);
+        leftSection.appendChild(infoDiv);
         
         
         const scoreDiv = document.createElement("div");
@@ -564,9 +885,106 @@ class UIManager {
     }
     
     this.gameResultDisplay.appendChild(scoreList);
+
+    if (resultPayload.awards && resultPayload.awards.length > 0) {
+      this.gameResultDisplay.appendChild(this.createAwardList(resultPayload.awards));
+    }
+
+    // 이번 게임에 적용된 점수 규칙
+    const scoringDiv = document.createElement("div");
+    scoringDiv.className = "mt-3 text-center text-xs text-gray-500";
+    scoringDiv.textContent = `점수 규칙: ${this.formatScoringRules(resultPayload.scoring).join(" · ")}`;
+    this.gameResultDisplay.appendChild(scoringDiv);
+  }
+
+  createTeamResult(teamScores, winningTeam) {
+    const teamResult = document.createElement("div");
+    teamResult.className = "mb-4 text-center";
+
+    const winner = TEAM_INFO[winningTeam];
+    const titleDiv = document.createElement("div");
+    titleDiv.className = "text-xl font-bold mb-2";
+    titleDiv.textContent = winner ? `${winner.icon} ${winner.name} 팀 승리!` : "🤝 무승부";
+    if (winner) titleDiv.style.color = winner.color;
+    teamResult.appendChild(titleDiv);
+
+    const scoresDiv = document.createElement("div");
+    scoresDiv.className = "flex justify-center gap-3";
+    Object.keys(TEAM_INFO).forEach((team) => {
+      if (teamScores[team] === undefined) return;
+      const info = TEAM_INFO[team];
+      const teamDiv = document.createElement("div");
+      teamDiv.className = "px-4 py-2 rounded-lg text-white font-bold";
+      teamDiv.style.backgroundColor = info.color;
+      teamDiv.textContent = `${info.icon} ${info.name} ${teamScores[team]}점`;
+      scoresDiv.appendChild(teamDiv);
+    });
+    teamResult.appendChild(scoresDiv);
+
+    return teamResult;
+  }
+
+  formatCombatStats(stats) {
+    const accuracy = Math.round(stats.accuracy * 100);
+    const timeAlive = Math.floor(stats.time_alive_ms / 1000);
+    let text = `처치 ${stats.kills} · 데스 ${stats.deaths} · 준 피해 ${stats.damage_dealt} · 받은 피해 ${stats.damage_taken}`
+      + ` · 명중률 ${accuracy}% (${stats.hits}/${stats.swings}) · 최다 연속 처치 ${stats.longest_streak} · 생존 ${timeAlive}초`;
+
+    // 점수 규칙을 쓴 게임에서만 표시
+    if (stats.bonus_points) {
+      text += ` · 추가 점수 ${stats.bonus_points} (현상금 ${stats.bounties}, 복수 ${stats.revenges})`;
+    }
+    if (stats.points_lost) {
+      text += ` · 잃은 점수 ${stats.points_lost}`;
+    }
+    return text;
+  }
+
+  createAwardList(awards) {
+    const awardIcons = {
+      mvp: '🏅',
+      most_accurate: '🎯',
+      punching_bag: '🥊',
+    };
+
+    const awardList = document.createElement("div");
+    awardList.className = "mt-4 grid grid-cols-1 sm:grid-cols-3 gap-2";
+
+    awards.forEach((award) => {
+      let valueText = '';
+      if (award.id === 'most_accurate') {
+        valueText = `${Math.round(award.value * 100)}%`;
+      } else if (award.id === 'punching_bag') {
+        valueText = `받은 피해 ${award.value}`;
+      } else {
+        valueText = `${award.value}점`;
+      }
+
+      const awardItem = document.createElement("div");
+      awardItem.className = "p-3 rounded-lg bg-slate-700 text-center text-white";
+      awardItem.style.textShadow = '0 0 3px #000, 0 0 3px #000';
+
+      const titleDiv = document.createElement("div");
+      titleDiv.className = "font-bold";
+      titleDiv.textContent = `${awardIcons[award.id] || '🏆'} ${award.title}`;
+
+      const nameDiv = document.createElement("div");
+      nameDiv.textContent = award.nickname || award.player_id.substring(0, 6);
+
+      const valueDiv = document.createElement("div");
+      valueDiv.className = "text-xs text-gray-300";
+      valueDiv.textContent = valueText;
+
+      awardItem.appendChild(titleDiv);
+      awardItem.appendChild(nameDiv);
+      awardItem.appendChild(valueDiv);
+      awardList.appendChild(awardItem);
+    });
+
+    return awardList;
   }
 
-  updateHudPlayerInfo() {
+  updateHudPlayerInfo(teamScores) {
     // 플레이어 점수 목록
     const playerListContainer = this.gameHudTopLeft.querySelector('.player-scores-list') || document.createElement("div");
     if (!this.gameHudTopLeft.querySelector('.player-scores-list')) {
@@ -575,6 +993,22 @@ class UIManager {
     }
     
     playerListContainer.innerHTML = "";
+
+    // 팀 모드면 팀 점수
+    if (teamScores) {
+      const teamDiv = document.createElement("div");
+      teamDiv.className = "flex gap-2 mb-2 font-bold";
+      Object.keys(TEAM_INFO).forEach((team) => {
+        if (teamScores[team] === undefined) return;
+        const info = TEAM_INFO[team];
+        const span = document.createElement("span");
+        span.className = "px-2 py-0.5 rounded-md text-white";
+        span.style.backgroundColor = info.color;
+        span.textContent = `${info.icon} ${teamScores[team]}`;
+        teamDiv.appendChild(span);
+      });
+      playerListContainer.appendChild(teamDiv);
+    }
     const ul = document.createElement("ul");
 
     // 캐릭터와 이모지
@@ -598,13 +1032,14 @@ class UIManager {
       const character = playerOnMap ? playerOnMap.asset : null;
       const characterEmoji = characterEmojis[character] || '👤';
       const isSelf = p.id === stateManager.getClientId();
+      const teamIcon = TEAM_INFO[p.team] ? `<span style="margin-right:3px;">${TEAM_INFO[p.team].icon}</span>` : '';
 
       const li = document.createElement("li");
       li.style.marginBottom = "0.25rem";
       li.className = isSelf ? "font-bold text-yellow-300" : "text-slate-200";
       li.innerHTML = `<div class="flex items-center">
                         <span style="width:12px; height:12px; background-color:${color}; border-radius:50%; margin-right:5px; border:1px solid #fff;"></span>
-                        <span style="margin-right:3px;">${characterEmoji}</span>
+                        ${teamIcon}<span style="margin-right:3px;">${characterEmoji}</span>
                         <span>${nickname}: ${p.score}점</span>
                       </div>`;
       ul.appendChild(li);
@@ -685,6 +1120,77 @@ class UIManager {
     this.gameTimeLeftEl.textContent = formattedTime;
   }
 
+  // 킬 피드 표시
+  // 피격, 부활 이벤트는 피드에 표시하지 않음
+  addGameEvents(events) {
+    const maxEntries = 5;
+    const entryLifetime = 5000;
+
+    events.forEach((event) => {
+      const text = this.formatGameEvent(event);
+      if (!text) return;
+
+      const entry = document.createElement("div");
+      entry.textContent = text;
+      this.killFeedEl.prepend(entry);
+      setTimeout(() => entry.remove(), entryLifetime);
+    });
+
+    while (this.killFeedEl.children.length > maxEntries) {
+      this.killFeedEl.lastElementChild.remove();
+    }
+  }
+
+  formatGameEvent(event) {
+    const nameOf = (id) => {
+      const player = stateManager.getPlayer(id);
+      return player ? player.nickname : id.substring(0, 6);
+    };
+    const sourceNames = {
+      arena: '경기장 밖',
+      falling_crate: '낙하물',
+    };
+
+    const bonusNames = {
+      streak: '연속 처치',
+      bounty: '현상금',
+      revenge: '복수',
+    };
+
+    switch (event.type) {
+      case 'kill': {
+        // 점수 규칙에 따른 점수 변화
+        let scoreText = '';
+        if (event.points) {
+          scoreText += ` +${event.points}`;
+        }
+        if (event.bonuses && event.bonuses.length > 0) {
+          scoreText += ` (${event.bonuses.map((bonus) => bonusNames[bonus] || bonus).join(', ')})`;
+        }
+        if (event.points_lost) {
+          scoreText += ` / ${nameOf(event.victim_id)} -${event.points_lost}`;
+        }
+
+        if (!event.attacker_id) {
+          return `💀 ${nameOf(event.victim_id)} (${sourceNames[event.source] || '사고'})${scoreText}`;
+        }
+        return `🔨 ${nameOf(event.attacker_id)} → ${nameOf(event.victim_id)}${scoreText}`;
+      }
+      case 'streak':
+        return `🔥 ${nameOf(event.player_id)} ${event.streak}연속 처치!`;
+      case 'pickup':
+        return `🎁 ${nameOf(event.player_id)} 아이템 획득`;
+      case 'disconnect':
+        return `🔌 ${nameOf(event.player_id)} 연결 끊김`;
+      default:
+        return null;
+    }
+  }
+
+  clearKillFeed() {
+    this.killFeedEl.innerHTML = "";
+  }
+
   showGameCountdown(text) {
     this.gameCountdownOverlay.classList.remove("hidden");
     this.gameCountdownOverlay.textContent = text;
@@ -694,6 +1200,32 @@ class UIManager {
     this.gameCountdownOverlay.classList.add("hidden");
   }
 
+  // 서버 공지 표시
+  // expiresAt(Unix ms)이 없으면 클릭해서 닫을 때까지 표시
+  showAnnouncement(message, severity = "info", expiresAt = 0) {
+    const warning = severity === "warning";
+    this.announcementBanner.textContent = `${warning ? "⚠️" : "📢"} ${message}`;
+    this.announcementBanner.classList.toggle("bg-sky-600", !warning);
+    this.announcementBanner.classList.toggle("bg-amber-500", warning);
+    this.announcementBanner.classList.remove("hidden");
+    this.announcementBanner.onclick = () => this.hideAnnouncement();
+
+    clearTimeout(this.announcementTimer);
+    if (expiresAt) {
+      const remaining = expiresAt - Date.now();
+      if (remaining <= 0) {
+        this.hideAnnouncement();
+        return;
+      }
+      this.announcementTimer = setTimeout(() => this.hideAnnouncement(), remaining);
+    }
+  }
+
+  hideAnnouncement() {
+    clearTimeout(this.announcementTimer);
+    this.announcementBanner.classList.add("hidden");
+  }
+
 setupColorSelection() {
   document.querySelectorAll('.game-color-option').forEach(button => {
     button.addEventListener('click', () => {
@@ -1,6 +1,10 @@
 /**
  * WebSocket 연결 및 메시지 처리 모듈
  */
+
+// 프로필(닉네임, 색상, 캐릭터) 관련 에러 코드
+const PROFILE_ERROR_CODES = ["invalid_nickname", "inappropriate_nickname", "nickname_taken", "invalid_color", "unknown_character"];
+
 class WebSocketManager {
   constructor() {
     const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
@@ -8,6 +12,11 @@ class WebSocketManager {
     this.WS_URL = `${protocol}//${host}/ws`;
     
     this.ws = null;
+
+    // 다른 노드로 리다이렉트 시 재접속 정보
+    this.profile = null;
+    this.pendingJoinRoomId = null;
+    this.redirecting = false;
   }
 
   connect(nickname, color, character) {
@@ -16,6 +25,7 @@ class WebSocketManager {
       return;
     }
 
+    this.profile = { nickname, color, character };
     this.ws = new WebSocket(this.WS_URL);
     logger.updateConnectionStatus("연결중...", true);
 
@@ -25,6 +35,12 @@ class WebSocketManager {
       this.sendMessage("set_nickname_color", { nickname, color, character });
       uiManager.showMainUISection(uiManager.lobbySection);
       this.sendMessage("list_rooms", {});
+
+      // 리다이렉트로 재접속한 경우 방 참가
+      if (this.pendingJoinRoomId) {
+        this.sendMessage("join_room", { room_id: this.pendingJoinRoomId });
+        this.pendingJoinRoomId = null;
+      }
     };
 
     this.ws.onmessage = (event) => {
@@ -35,10 +51,19 @@ class WebSocketManager {
       this.handleServerMessage(message);
     };
 
-    this.ws.onclose = () => {
+    this.ws.onclose = (event) => {
+      // 리다이렉트 중에는 새 노드로 재접속
+      if (this.redirecting) {
+        this.redirecting = false;
+        this.ws = null;
+        const { nickname, color, character } = this.profile;
+        this.connect(nickname, color, character);
+        return;
+      }
       logger.updateConnectionStatus("오프라인", false);
-      logger.logMessage("서버와 연결이 끊어졌습니다.", "error");
+      logger.logMessage(`서버와 연결이 끊어졌습니다.${event.reason ? ` (${event.reason})` : ""}`, "error");
       window.gameRenderer.exitGameView();
+      stateManager.clearWatchingReplay();
       uiManager.showMainUISection(uiManager.initialSetupSection);
       this.ws = null;
     };
@@ -52,6 +77,15 @@ class WebSocketManager {
     };
   }
 
+  // 다른 노드에 있는 방으로 이동
+  redirectToNode(wsUrl, roomId) {
+    if (!wsUrl || !this.ws) return;
+    this.WS_URL = wsUrl;
+    this.pendingJoinRoomId = roomId;
+    this.redirecting = true;
+    this.ws.close();
+  }
+
   sendMessage(type, payload) {
     if (this.ws && this.ws.readyState === WebSocket.OPEN) {
       const message = { type, payload };
@@ -70,6 +104,11 @@ class WebSocketManager {
         logger.logMessage(`내 ID 할당됨: ${payload.user_id}`);
         break;
 
+      case "profile_updated":
+        this.profile = { nickname: payload.nickname, color: payload.color, character: payload.character };
+        logger.logMessage(`프로필 설정 완료: ${payload.nickname}`, "success");
+        break;
+
       case "room_list_updated":
         if (uiManager.mainUiContainer.classList.contains("hidden")) return;
         uiManager.updateRoomList(payload.rooms);
@@ -165,7 +204,8 @@ class WebSocketManager {
         break;
 
       case "room_state_updated":
-        stateManager.setRoomInfo(payload);
+        // 상태 변경 메세지에는 최대 인원이 없으므로 기존 방 정보에 덮어씀
+        stateManager.setRoomInfo({ ...stateManager.getRoomInfo(), ...payload });
         stateManager.updatePlayersFromArray(payload.players);
         
         if (payload.room_id === stateManager.getCurrentRoomId() &&
@@ -193,6 +233,11 @@ class WebSocketManager {
         
         // 애니메이션 시작
         window.gameRenderer.animateThreeJS();
+
+        // 리플레이는 서버가 녹화된 순서대로 보내므로 로딩 완료 알림 없음
+        if (stateManager.isWatchingReplay()) {
+          break;
+        }
         
         // 로딩 완료 메시지 표시
         uiManager.showGameCountdown("다른 플레이어들을 기다리는 중입니다...");
@@ -221,20 +266,79 @@ class WebSocketManager {
       case "game_state_update":
         stateManager.updatePlayersFromArray(payload.players);
         window.gameRenderer.updatePlayerMeshes();
+        window.gameRenderer.updateAttackEntities(payload.game_specific_state?.attack_entities || []);
+        window.gameRenderer.updateArena(payload.game_specific_state?.arena);
+        window.gameRenderer.updateHazards(payload.game_specific_state?.hazards || []);
+        window.gameRenderer.updatePings(payload.game_specific_state?.pings || []);
         uiManager.updateGameTimeLeft(payload.time_left);
-        uiManager.updateHudPlayerInfo();
+        uiManager.updateHudPlayerInfo(payload.team_scores);
+        break;
+
+      case "game_event":
+        uiManager.addGameEvents(payload.events || []);
+        window.soundManager.playGameEvents(payload.events || []);
         break;
 
       case "game_ended":
+        uiManager.clearKillFeed();
         window.gameRenderer.exitGameView();
         uiManager.updateGameResultUI(payload);
         uiManager.showMainUISection(uiManager.gameResultSection);
+        // 리플레이는 결과 화면에서 끝내고 로비로 돌아감 (돌아갈 대기실 없음)
+        if (stateManager.isWatchingReplay()) {
+          this.sendMessage("replay_control", { action: "stop" });
+          stateManager.clearWatchingReplay();
+          break;
+        }
         uiManager.startAutoReturnTimer(10);
         break;
 
+      case "replay_status":
+        if (stateManager.isWatchingReplay()) {
+          uiManager.updateReplayStatus(payload);
+        }
+        break;
+
+      case "room_redirect":
+        logger.logMessage(`방 ${payload.room_id}은(는) 다른 서버(${payload.node_id})에 있습니다. 이동 중...`);
+        this.redirectToNode(payload.ws_url, payload.room_id);
+        break;
+
+      case "room_closed":
+        // 관리자 등에 의해 방이 닫힘
+        window.gameRenderer.exitGameView();
+        uiManager.stopAutoReturnTimer();
+        stateManager.clearCurrentRoom();
+        uiManager.showMainUISection(uiManager.lobbySection);
+        this.sendMessage("list_rooms", {});
+        uiManager.showAnnouncement("방이 종료되었습니다.", "info", Date.now() + 10000);
+        break;
+
+      case "announcement":
+        uiManager.showAnnouncement(payload.message, payload.severity, payload.expires_at);
+        break;
+
+      case "balance_updated":
+        logger.logMessage(`게임 밸런스 변경: ${payload.version}`);
+        break;
+
+      case "server_shutting_down":
+        // 게임 진행을 막지 않도록 연결 상태 표시에 카운트다운 출력
+        logger.updateConnectionStatus(`서버 종료 ${payload.seconds_left}초 전`, true);
+        logger.logMessage(`서버가 ${payload.seconds_left}초 후 종료됩니다.`, "error");
+        break;
+
       case "error":
         alert(`${payload.message}`);
         logger.logMessage(`오류: ${payload.message}`, "error");
+        // 리플레이 화면에 들어가기 전에 거부되면 시청 상태 해제
+        if (stateManager.isWatchingReplay() && !uiManager.mainUiContainer.classList.contains("hidden")) {
+          stateManager.clearWatchingReplay();
+        }
+        // 프로필이 거부되면 연결을 끊고 설정 화면으로 돌아감
+        if (PROFILE_ERROR_CODES.includes(payload.code) && !stateManager.getCurrentRoomId()) {
+          this.ws.close();
+        }
         break;
 
       default: